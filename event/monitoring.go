@@ -35,6 +35,10 @@ type CommandStartedEvent struct {
 	// ServiceID contains the ID of the server to which the command was sent if it is running behind a load balancer.
 	// Otherwise, it is unset.
 	ServiceID *primitive.ObjectID
+	// OperationName contains the name given to the operation that sent the command by the application, such as with
+	// the OperationName option of the IndexView methods. It is only used for client-side monitoring and is not sent
+	// to the server. If no name was given, it is empty.
+	OperationName string
 }
 
 // CommandFinishedEvent represents a generic command finishing.
@@ -58,6 +62,9 @@ type CommandFinishedEvent struct {
 	// ServiceID contains the ID of the server to which the command was sent if it is running behind a load balancer.
 	// Otherwise, it is unset.
 	ServiceID *primitive.ObjectID
+	// OperationName contains the name given to the operation that sent the command by the application. If no name was
+	// given, it is empty.
+	OperationName string
 }
 
 // CommandSucceededEvent represents an event generated when a command's execution succeeds.
@@ -17,6 +17,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/mongo/address"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
@@ -123,6 +124,21 @@ func NewCursorFromDocuments(documents []interface{}, err error, registry *bsonco
 // ID returns the ID of this cursor, or 0 if the cursor has been closed or exhausted.
 func (c *Cursor) ID() int64 { return c.bc.ID() }
 
+// ServerAddress returns the address of the server that created this cursor. All getMore and killCursors commands for
+// the cursor are sent to this server, so the address can be used to direct a related operation to the same server. It
+// returns an empty address if the cursor was not created by a server command, such as a cursor returned by
+// NewCursorFromDocuments.
+//
+// The session used by the cursor is not exposed. To run related operations in the same session, pass an explicit
+// session to the operation that creates the cursor using NewSessionContext.
+func (c *Cursor) ServerAddress() address.Address {
+	bc, ok := c.bc.(*driver.BatchCursor)
+	if !ok {
+		return ""
+	}
+	return bc.ServerDescription().Addr
+}
+
 // Next gets the next document for this cursor. It returns true if there were no errors and the cursor has not been
 // exhausted.
 //
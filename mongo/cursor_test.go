@@ -15,6 +15,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/internal/assert"
 	"go.mongodb.org/mongo-driver/internal/require"
+	"go.mongodb.org/mongo-driver/mongo/address"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
@@ -245,6 +246,13 @@ func TestNewCursorFromDocuments(t *testing.T) {
 		assert.Nil(t, err, "Close error: %v", err)
 	})
 
+	t.Run("server address is empty", func(t *testing.T) {
+		cur, err := NewCursorFromDocuments([]interface{}{bson.D{{"_id", 0}}}, nil, nil)
+		assert.Nil(t, err, "NewCursorFromDocuments error: %v", err)
+		assert.Equal(t, address.Address(""), cur.ServerAddress(), "expected empty server address, got %q",
+			cur.ServerAddress())
+	})
+
 	// Mock an error in a Cursor.
 	t.Run("mock Find with error", func(t *testing.T) {
 		mockErr := fmt.Errorf("mock error")
@@ -10,6 +10,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -483,6 +484,40 @@ func (db *Database) ListCollectionNames(ctx context.Context, filter interface{},
 	return names, nil
 }
 
+// AllIndexes executes a listCollections command and then a listIndexes command for each collection in the database,
+// returning the index specifications keyed by collection name. Views are skipped because they cannot have indexes,
+// and system collections are skipped unless the IncludeSystemCollections option is set. A collection that is dropped
+// after it is listed is reported with no indexes.
+//
+// The opts parameter can be used to specify options for this operation (see the options.AllIndexesOptions
+// documentation).
+func (db *Database) AllIndexes(
+	ctx context.Context,
+	opts ...*options.AllIndexesOptions,
+) (map[string][]*IndexSpecification, error) {
+	ao := options.MergeAllIndexesOptions(opts...)
+	includeSystem := ao.IncludeSystemCollections != nil && *ao.IncludeSystemCollections
+
+	colls, err := db.ListCollectionSpecifications(ctx, bson.D{})
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := make(map[string][]*IndexSpecification, len(colls))
+	for _, coll := range colls {
+		if coll.Type == "view" || (!includeSystem && strings.HasPrefix(coll.Name, "system.")) {
+			continue
+		}
+
+		specs, err := db.Collection(coll.Name).Indexes().ListSpecifications(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error listing indexes for collection %q: %w", coll.Name, err)
+		}
+		indexes[coll.Name] = specs
+	}
+	return indexes, nil
+}
+
 // ReadConcern returns the read concern used to configure the Database object.
 func (db *Database) ReadConcern() *readconcern.ReadConcern {
 	return db.readConcern
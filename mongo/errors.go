@@ -225,6 +225,142 @@ func (e MongocryptdError) Unwrap() error {
 	return e.Wrapped
 }
 
+// IndexModelError is returned by IndexView.CreateMany when an error can be attributed to one of the IndexModels passed
+// to it.
+type IndexModelError struct {
+	// The position of the offending model in the models slice.
+	Index int
+	// The name of the offending index.
+	Name string
+	// The error returned by the server, or the client-side error for the model.
+	Wrapped error
+}
+
+// Error implements the error interface.
+func (e IndexModelError) Error() string {
+	return fmt.Sprintf("models[%d] (%q) failed: %v", e.Index, e.Name, e.Wrapped)
+}
+
+// Unwrap returns the underlying error.
+func (e IndexModelError) Unwrap() error {
+	return e.Wrapped
+}
+
+// PartialIndexCreationError is returned by IndexView.CreateMany when the models are sent in several batches because
+// the CreateIndexesOptions.BatchSize option is set and a batch fails after earlier batches succeeded. The indexes in
+// Created exist on the server, so callers can skip them when retrying or drop them to roll back.
+type PartialIndexCreationError struct {
+	// The names of the indexes that were created, in the order in which they were sent to the server.
+	Created []string
+	// The error that stopped index creation.
+	Wrapped error
+}
+
+// Error implements the error interface.
+func (e PartialIndexCreationError) Error() string {
+	return fmt.Sprintf("%d indexes were created before an error occurred: %v", len(e.Created), e.Wrapped)
+}
+
+// Unwrap returns the underlying error.
+func (e PartialIndexCreationError) Unwrap() error {
+	return e.Wrapped
+}
+
+// InvalidCollationLocaleError is returned by IndexView.CreateMany when the server rejects the locale of an index's
+// collation. The supported locales depend on the ICU version of the server, so a locale that is valid for one server
+// version may be rejected by another. It is wrapped in an IndexModelError if the model with the locale can be
+// identified.
+type InvalidCollationLocaleError struct {
+	// The rejected locale, or an empty string if it could not be determined.
+	Locale string
+	// The error returned by the server.
+	Wrapped error
+}
+
+// Error implements the error interface.
+func (e InvalidCollationLocaleError) Error() string {
+	if e.Locale == "" {
+		return fmt.Sprintf("collation locale is not supported by the server: %v", e.Wrapped)
+	}
+	return fmt.Sprintf("collation locale %q is not supported by the server: %v", e.Locale, e.Wrapped)
+}
+
+// Unwrap returns the underlying error.
+func (e InvalidCollationLocaleError) Unwrap() error {
+	return e.Wrapped
+}
+
+// ServerVersionError is returned by IndexView.CreateMany when the MinServerVersion option is set and the server is older
+// than the required version. No indexes are created.
+type ServerVersionError struct {
+	// The minimum version required by the MinServerVersion option.
+	MinVersion string
+	// The version reported by the server.
+	ServerVersion string
+}
+
+// Error implements the error interface.
+func (e ServerVersionError) Error() string {
+	return fmt.Sprintf("server version %s is older than the required version %s", e.ServerVersion, e.MinVersion)
+}
+
+// IndexRebuildError is returned by IndexView.Rebuild when the index was dropped but could not be recreated. The
+// collection no longer has the index, and Model can be passed to IndexView.CreateOne to retry.
+type IndexRebuildError struct {
+	// The name of the dropped index.
+	Name string
+	// The model that the index was to be recreated from.
+	Model IndexModel
+	// The error returned when recreating the index.
+	Wrapped error
+}
+
+// Error implements the error interface.
+func (e IndexRebuildError) Error() string {
+	return fmt.Sprintf("index %q was dropped but could not be recreated: %v", e.Name, e.Wrapped)
+}
+
+// Unwrap returns the underlying error.
+func (e IndexRebuildError) Unwrap() error {
+	return e.Wrapped
+}
+
+// DuplicateKeyConflict is returned by IndexView.CreateMany when a unique index cannot be built because documents in
+// the collection have duplicate values for its keys.
+type DuplicateKeyConflict struct {
+	// The keys document of the unique index that could not be built.
+	KeyPattern bson.Raw
+	// A sample of the duplicated values, as a document with the same fields as KeyPattern. This is nil if the server
+	// did not report the values.
+	KeyValue bson.Raw
+	// The partial filter expression of the index, which limits the documents that must have unique values to those
+	// matching it. This is nil if the index is not a partial index or the conflict could not be attributed to a single
+	// IndexModel.
+	PartialFilterExpression bson.Raw
+	// The error returned by the server.
+	Wrapped error
+}
+
+// Error implements the error interface.
+func (e DuplicateKeyConflict) Error() string {
+	var b strings.Builder
+	b.WriteString("duplicate values ")
+	if e.KeyValue != nil {
+		fmt.Fprintf(&b, "%v ", e.KeyValue)
+	}
+	fmt.Fprintf(&b, "for unique index %v", e.KeyPattern)
+	if e.PartialFilterExpression != nil {
+		fmt.Fprintf(&b, " with partial filter %v", e.PartialFilterExpression)
+	}
+	fmt.Fprintf(&b, ": %v", e.Wrapped)
+	return b.String()
+}
+
+// Unwrap returns the underlying error.
+func (e DuplicateKeyConflict) Unwrap() error {
+	return e.Wrapped
+}
+
 // LabeledError is an interface for errors with labels.
 type LabeledError interface {
 	error
@@ -0,0 +1,326 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// IndexesSkippedError is returned by IndexView.CopyTo when some of the source indexes could not be recreated on the
+// destination collection. The other indexes are still created.
+type IndexesSkippedError struct {
+	// The reason each index was skipped, keyed by index name.
+	Skipped map[string]error
+}
+
+// Error implements the error interface.
+func (e IndexesSkippedError) Error() string {
+	names := make([]string, 0, len(e.Skipped))
+	for name := range e.Skipped {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	reasons := make([]string, 0, len(names))
+	for _, name := range names {
+		reasons = append(reasons, fmt.Sprintf("%q: %v", name, e.Skipped[name]))
+	}
+	return fmt.Sprintf("%d indexes were skipped: %s", len(names), strings.Join(reasons, ", "))
+}
+
+// CopyTo lists the indexes on the IndexView's collection and creates them on dst with the same keys and options. The
+// "_id_" index is not copied unless the IncludeIDIndex option is set. It returns the names of the created indexes.
+//
+// Indexes that cannot be recreated, such as those with options that cannot be passed to createIndexes or those the
+// server rejects for the destination collection, are skipped and reported in an IndexesSkippedError that is returned
+// along with the names of the indexes that were created.
+//
+// The opts parameter can be used to specify options for this operation (see the options.CopyIndexesOptions
+// documentation).
+func (iv IndexView) CopyTo(ctx context.Context, dst *Collection, opts ...*options.CopyIndexesOptions) ([]string, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if dst == nil {
+		return nil, errors.New("destination collection cannot be nil")
+	}
+
+	co := options.MergeCopyIndexesOptions(opts...)
+	includeID := co.IncludeIDIndex != nil && *co.IncludeIDIndex
+
+	specs, err := iv.listRawSpecs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	skipped := make(map[string]error)
+	models := make([]IndexModel, 0, len(specs))
+	for _, spec := range specs {
+		name, _ := spec.Lookup("name").StringValueOK()
+		if name == "_id_" && !includeID {
+			continue
+		}
+
+		model, err := indexModelFromSpec(spec)
+		if err != nil {
+			skipped[name] = err
+			continue
+		}
+		models = append(models, model)
+	}
+
+	var names []string
+	for len(models) > 0 {
+		names, err = dst.Indexes().CreateMany(ctx, models)
+		if err == nil {
+			break
+		}
+
+		var modelErr IndexModelError
+		if !errors.As(err, &modelErr) {
+			return nil, err
+		}
+		skipped[modelErr.Name] = modelErr.Wrapped
+		models = append(models[:modelErr.Index], models[modelErr.Index+1:]...)
+	}
+
+	if len(skipped) > 0 {
+		return names, IndexesSkippedError{Skipped: skipped}
+	}
+	return names, nil
+}
+
+// UnknownIndexOptionsError is returned by IndexModelFromLegacy and UnmarshalIndexModelJSON along with the parsed
+// IndexModel if the specification contains fields that do not correspond to a known index option. Those fields are not
+// included in the IndexModel.
+type UnknownIndexOptionsError struct {
+	// The names of the unknown fields, in the order they appear in the specification.
+	Fields []string
+}
+
+// Error implements the error interface.
+func (e UnknownIndexOptionsError) Error() string {
+	return fmt.Sprintf("unknown index options were ignored: %s", strings.Join(e.Fields, ", "))
+}
+
+// IndexOptionTypeError is returned when an index specification contains a known field whose value has a type that
+// cannot be converted to the corresponding index option, such as a string "unique" field.
+type IndexOptionTypeError struct {
+	// The name of the field.
+	Option string
+	// The BSON type of the field's value.
+	Type bsontype.Type
+}
+
+// Error implements the error interface.
+func (e IndexOptionTypeError) Error() string {
+	return fmt.Sprintf("index option %q has unexpected type %v", e.Option, e.Type)
+}
+
+// IndexModelFromLegacy converts an index specification in the legacy ensureIndex format, such as
+// {key: {a: 1}, name: "a_1", unique: true}, into an IndexModel. The "key" document becomes the model's Keys and known
+// fields such as "unique", "sparse", "expireAfterSeconds", and "partialFilterExpression" are mapped to the
+// corresponding IndexOptions. The "v", "ns", and "background" fields are ignored. Numeric values are accepted for
+// boolean options such as "unique", as legacy specifications often use {unique: 1}. An IndexOptionTypeError is returned
+// if a known field has a value that cannot be converted.
+//
+// If the specification contains other fields, the IndexModel is returned with an UnknownIndexOptionsError listing them
+// so that callers can decide whether to proceed without those options.
+func IndexModelFromLegacy(spec bson.D) (IndexModel, error) {
+	raw, err := bson.Marshal(spec)
+	if err != nil {
+		return IndexModel{}, err
+	}
+
+	model, unknown, err := parseIndexSpec(raw)
+	if err != nil {
+		return IndexModel{}, err
+	}
+	if len(unknown) > 0 {
+		return model, UnknownIndexOptionsError{Fields: unknown}
+	}
+	return model, nil
+}
+
+// indexModelFromSpec converts an index specification document returned by listIndexes into an IndexModel that can be
+// passed to CreateMany. An error is returned if the specification contains an option that cannot be recreated.
+func indexModelFromSpec(spec bson.Raw) (IndexModel, error) {
+	model, unknown, err := parseIndexSpec(spec)
+	if err != nil {
+		return IndexModel{}, err
+	}
+	if len(unknown) > 0 {
+		return IndexModel{}, fmt.Errorf("index option %q cannot be recreated", unknown[0])
+	}
+	return model, nil
+}
+
+// parseIndexSpec converts an index specification document into an IndexModel. The names of fields that do not
+// correspond to a known index option are returned separately and are not included in the IndexModel.
+func parseIndexSpec(spec bson.Raw) (IndexModel, []string, error) {
+	elems, err := spec.Elements()
+	if err != nil {
+		return IndexModel{}, nil, err
+	}
+
+	var keys, weights bsoncore.Document
+	var unknown []string
+	opts := options.Index()
+	for _, elem := range elems {
+		val := bsoncore.Value{Type: elem.Value().Type, Data: elem.Value().Value}
+
+		switch key := elem.Key(); key {
+		case "v", "ns", "background":
+			// The index version is chosen by the destination server, and the namespace and background fields do not
+			// affect the index.
+		case "key":
+			doc, ok := val.DocumentOK()
+			if !ok {
+				return IndexModel{}, nil, IndexOptionTypeError{Option: key, Type: val.Type}
+			}
+			keys = doc
+		case "name", "default_language", "language_override":
+			str, ok := val.StringValueOK()
+			if !ok {
+				return IndexModel{}, nil, IndexOptionTypeError{Option: key, Type: val.Type}
+			}
+			switch key {
+			case "name":
+				opts.SetName(str)
+			case "default_language":
+				opts.SetDefaultLanguage(str)
+			case "language_override":
+				opts.SetLanguageOverride(str)
+			}
+		case "unique", "sparse", "hidden":
+			b, ok := indexBoolean(val)
+			if !ok {
+				return IndexModel{}, nil, IndexOptionTypeError{Option: key, Type: val.Type}
+			}
+			switch key {
+			case "unique":
+				opts.SetUnique(b)
+			case "sparse":
+				opts.SetSparse(b)
+			case "hidden":
+				opts.SetHidden(b)
+			}
+		case "expireAfterSeconds", "textIndexVersion", "2dsphereIndexVersion", "bits", "bucketSize":
+			n, ok := indexNumber(val)
+			if !ok {
+				return IndexModel{}, nil, IndexOptionTypeError{Option: key, Type: val.Type}
+			}
+			switch key {
+			case "expireAfterSeconds":
+				opts.SetExpireAfterSeconds(int32(n))
+			case "textIndexVersion":
+				opts.SetTextVersion(int32(n))
+			case "2dsphereIndexVersion":
+				opts.SetSphereVersion(int32(n))
+			case "bits":
+				opts.SetBits(int32(n))
+			case "bucketSize":
+				opts.SetBucketSize(int32(n))
+			}
+		case "min", "max":
+			n, ok := indexNumber(val)
+			if !ok {
+				return IndexModel{}, nil, IndexOptionTypeError{Option: key, Type: val.Type}
+			}
+			if key == "min" {
+				opts.SetMin(n)
+			} else {
+				opts.SetMax(n)
+			}
+		case "weights", "partialFilterExpression", "wildcardProjection", "storageEngine":
+			doc, ok := val.DocumentOK()
+			if !ok {
+				return IndexModel{}, nil, IndexOptionTypeError{Option: key, Type: val.Type}
+			}
+			switch key {
+			case "weights":
+				weights = doc
+				opts.SetWeights(bson.Raw(doc))
+			case "partialFilterExpression":
+				opts.SetPartialFilterExpression(bson.Raw(doc))
+			case "wildcardProjection":
+				opts.SetWildcardProjection(bson.Raw(doc))
+			case "storageEngine":
+				opts.SetStorageEngine(bson.Raw(doc))
+			}
+		case "collation":
+			doc, ok := val.DocumentOK()
+			if !ok {
+				return IndexModel{}, nil, IndexOptionTypeError{Option: key, Type: val.Type}
+			}
+			var collation options.Collation
+			if err := bson.Unmarshal(doc, &collation); err != nil {
+				return IndexModel{}, nil, fmt.Errorf("error decoding collation: %w", err)
+			}
+			opts.SetCollation(&collation)
+		default:
+			unknown = append(unknown, key)
+		}
+	}
+
+	if keys == nil {
+		return IndexModel{}, nil, errors.New("index specification does not contain a keys document")
+	}
+	if weights != nil {
+		keys = textIndexKeysFromSpec(keys, weights)
+	}
+	return IndexModel{Keys: bson.Raw(keys), Options: opts}, unknown, nil
+}
+
+// indexBoolean returns the value of a boolean index option. Like the server, it treats a numeric value as true if it is
+// not zero, which older servers and legacy specifications use for options such as {unique: 1}.
+func indexBoolean(val bsoncore.Value) (bool, bool) {
+	if b, ok := val.BooleanOK(); ok {
+		return b, true
+	}
+	if n, ok := indexNumber(val); ok {
+		return n != 0, true
+	}
+	return false, false
+}
+
+// textIndexKeysFromSpec replaces the {_fts: "text", _ftsx: 1} pair that the server reports for a text index with a
+// "text" key for each field in the index's weights document. This reverses normalizeTextIndexKeys.
+func textIndexKeysFromSpec(keys, weights bsoncore.Document) bsoncore.Document {
+	elems, err := keys.Elements()
+	if err != nil {
+		return keys
+	}
+	weightElems, err := weights.Elements()
+	if err != nil {
+		return keys
+	}
+
+	idx, doc := bsoncore.AppendDocumentStart(nil)
+	for _, elem := range elems {
+		switch elem.Key() {
+		case "_fts":
+			for _, w := range weightElems {
+				doc = bsoncore.AppendStringElement(doc, w.Key(), "text")
+			}
+		case "_ftsx":
+		default:
+			doc = bsoncore.AppendValueElement(doc, elem.Key(), elem.Value())
+		}
+	}
+	doc, _ = bsoncore.AppendDocumentEnd(doc, idx)
+	return doc
+}
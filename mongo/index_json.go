@@ -0,0 +1,101 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// MarshalIndexModelJSON converts model into a JSON index specification in the form used by createIndexes, such as
+// {"key": {"a": 1}, "name": "a_1", "unique": true}, so that index definitions can be kept in configuration files. The
+// "key" field comes first, followed by the options that are set, in a fixed order. Values are written as relaxed
+// extended JSON, so plain numbers, strings, and booleans are written as-is and BSON-specific types such as dates in
+// a partial filter expression use extended JSON wrappers like {"$date": ...}.
+//
+// The result can be converted back into an IndexModel with UnmarshalIndexModelJSON.
+func MarshalIndexModelJSON(model IndexModel) ([]byte, error) {
+	if model.Keys == nil {
+		return nil, errors.New("index model keys cannot be nil")
+	}
+	if isUnorderedMap(model.Keys) {
+		return nil, ErrMapForOrderedArgument{"keys"}
+	}
+
+	keys, err := marshal(model.Keys, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	opts := model.Options
+	if opts == nil {
+		opts = options.Index()
+	}
+	optsDoc, err := indexOptionsDoc(opts, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	idx, spec := bsoncore.AppendDocumentStart(nil)
+	spec = bsoncore.AppendDocumentElement(spec, "key", keys)
+	spec = append(spec, optsDoc...)
+	spec, err = bsoncore.AppendDocumentEnd(spec, idx)
+	if err != nil {
+		return nil, err
+	}
+	return bson.MarshalExtJSON(bson.Raw(spec), false, false)
+}
+
+// UnmarshalIndexModelJSON converts a JSON index specification, such as one written by MarshalIndexModelJSON, into an
+// IndexModel. The "key" field is required and becomes the model's Keys with its field order preserved. The other
+// fields are mapped to the corresponding IndexOptions. Both relaxed and canonical extended JSON are accepted, and
+// numbers are accepted for boolean options such as "unique". An IndexOptionTypeError is returned if a known field has a
+// value that cannot be converted.
+//
+// If the specification contains fields that do not correspond to a known index option, an
+// UnknownIndexOptionsError listing them is returned along with the IndexModel.
+func UnmarshalIndexModelJSON(data []byte) (IndexModel, error) {
+	var doc bson.D
+	if err := bson.UnmarshalExtJSON(data, false, &doc); err != nil {
+		return IndexModel{}, fmt.Errorf("error decoding index specification: %w", err)
+	}
+	b, err := bson.Marshal(doc)
+	if err != nil {
+		return IndexModel{}, err
+	}
+
+	raw := bson.Raw(b)
+	model, unknown, err := parseIndexSpec(raw)
+	if err != nil {
+		return IndexModel{}, err
+	}
+
+	// parseIndexSpec ignores the fields that do not need to be copied to another collection, but they are part of the
+	// model's options when it is read from a configuration file.
+	if val, err := raw.LookupErr("v"); err == nil {
+		n, ok := indexNumber(bsoncore.Value{Type: val.Type, Data: val.Value})
+		if !ok {
+			return IndexModel{}, IndexOptionTypeError{Option: "v", Type: val.Type}
+		}
+		model.Options.SetVersion(int32(n))
+	}
+	if val, err := raw.LookupErr("background"); err == nil {
+		background, ok := indexBoolean(bsoncore.Value{Type: val.Type, Data: val.Value})
+		if !ok {
+			return IndexModel{}, IndexOptionTypeError{Option: "background", Type: val.Type}
+		}
+		model.Options.SetBackground(background)
+	}
+
+	if len(unknown) > 0 {
+		return model, UnknownIndexOptionsError{Fields: unknown}
+	}
+	return model, nil
+}
@@ -0,0 +1,155 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// indexDescriptionMarker separates an index's base name from the description encoded by WithIndexDescription. It
+	// is longer than a single character so that ordinary names that contain "#", such as "orders#v2", are not mistaken
+	// for names with a description.
+	indexDescriptionMarker = "#desc:"
+
+	// maxIndexDescriptionLength is the maximum length in bytes of an encoded index description.
+	maxIndexDescriptionLength = 64
+
+	// maxDescribedIndexNameLength is the maximum length in bytes of an index name with a description. It is the limit
+	// on full index namespaces in MongoDB versions before 4.2, so that described names stay usable on older servers.
+	maxDescribedIndexNameLength = 127
+)
+
+// AscendingIndex returns an IndexModel for an ascending index on a single field.
+func AscendingIndex(field string) IndexModel {
+	return IndexModel{Keys: bson.D{{field, 1}}}
+}
+
+// DescendingIndex returns an IndexModel for a descending index on a single field.
+func DescendingIndex(field string) IndexModel {
+	return IndexModel{Keys: bson.D{{field, -1}}}
+}
+
+// CompoundIndex returns an IndexModel for an index on the fields in keys, in order. Each value in keys should be 1 for
+// ascending order, -1 for descending order, or a string index type such as "text" or "2dsphere".
+func CompoundIndex(keys bson.D) IndexModel {
+	return IndexModel{Keys: keys}
+}
+
+// TextIndex returns an IndexModel for a text index on the given fields. A collection can have at most one text index,
+// so all fields that should be searched must be passed in a single call.
+func TextIndex(fields ...string) IndexModel {
+	keys := make(bson.D, 0, len(fields))
+	for _, field := range fields {
+		keys = append(keys, bson.E{field, "text"})
+	}
+	return IndexModel{Keys: keys}
+}
+
+// TTLIndex returns an IndexModel for an ascending index on a date field that removes documents ttl after the time
+// stored in the field. The server only supports whole seconds, so ttl is truncated to a whole number of seconds.
+//
+// ttl must be between 0 and math.MaxInt32 seconds, which is about 68 years. TTLIndex panics if it is negative or
+// longer than that.
+func TTLIndex(field string, ttl time.Duration) IndexModel {
+	seconds := ttl / time.Second
+	if ttl < 0 || seconds > math.MaxInt32 {
+		panic(fmt.Sprintf("TTL index duration %v is out of range: it must be between 0 and %d seconds", ttl,
+			math.MaxInt32))
+	}
+	return IndexModel{
+		Keys:    bson.D{{field, 1}},
+		Options: options.Index().SetExpireAfterSeconds(int32(seconds)),
+	}
+}
+
+// UniqueIndex returns an IndexModel for an ascending index on a single field that rejects documents with duplicate
+// values for the field.
+func UniqueIndex(field string) IndexModel {
+	return IndexModel{
+		Keys:    bson.D{{field, 1}},
+		Options: options.Index().SetUnique(true),
+	}
+}
+
+// WithIndexDescription returns a copy of model whose index name records a short, human-readable description of the
+// index's purpose, which can be read back from the collection's index specifications with IndexDescription. MongoDB
+// does not store arbitrary annotations on indexes, so the description is appended to the model's name, or to the
+// name generated from its keys if no name is set, after a "#desc:" marker. Any description already recorded in the
+// name is replaced. Other "#" characters in the name are kept, so a name such as "orders#v2" becomes
+// "orders#v2#desc:<description>".
+//
+// The "%" and "#" characters in the description are percent-encoded so that the name can be decoded. An error is
+// returned if the description is empty, contains a null byte, or is longer than 64 bytes after encoding, or if the
+// resulting name is longer than 127 bytes.
+func WithIndexDescription(model IndexModel, description string) (IndexModel, error) {
+	if description == "" {
+		return IndexModel{}, errors.New("index description cannot be empty")
+	}
+	if strings.ContainsRune(description, 0) {
+		return IndexModel{}, errors.New("index description cannot contain a null byte")
+	}
+	encoded := strings.NewReplacer("%", "%25", "#", "%23").Replace(description)
+	if len(encoded) > maxIndexDescriptionLength {
+		return IndexModel{}, fmt.Errorf("encoded index description is %d bytes, which exceeds the limit of %d bytes",
+			len(encoded), maxIndexDescriptionLength)
+	}
+
+	if model.Keys == nil {
+		return IndexModel{}, errors.New("index model keys cannot be nil")
+	}
+	keys, err := marshal(model.Keys, nil, nil)
+	if err != nil {
+		return IndexModel{}, err
+	}
+	base, err := getOrGenerateIndexName(keys, model)
+	if err != nil {
+		return IndexModel{}, err
+	}
+	if i := strings.LastIndex(base, indexDescriptionMarker); i >= 0 {
+		base = base[:i]
+	}
+
+	name := base + indexDescriptionMarker + encoded
+	if len(name) > maxDescribedIndexNameLength {
+		return IndexModel{}, fmt.Errorf("index name %q is %d bytes, which exceeds the limit of %d bytes",
+			name, len(name), maxDescribedIndexNameLength)
+	}
+
+	opts := options.Index()
+	if model.Options != nil {
+		copied := *model.Options
+		opts = &copied
+	}
+	return IndexModel{Keys: model.Keys, Options: opts.SetName(name)}, nil
+}
+
+// IndexDescription returns the description recorded in the name of the index by WithIndexDescription. It returns false
+// if the name does not contain a "#desc:" marker followed by a description.
+func IndexDescription(spec *IndexSpecification) (string, bool) {
+	if spec == nil {
+		return "", false
+	}
+	i := strings.LastIndex(spec.Name, indexDescriptionMarker)
+	if i < 0 || i+len(indexDescriptionMarker) == len(spec.Name) {
+		return "", false
+	}
+
+	description, err := url.PathUnescape(spec.Name[i+len(indexDescriptionMarker):])
+	if err != nil {
+		return "", false
+	}
+	return description, true
+}
@@ -0,0 +1,515 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// IndexPlan describes the changes needed to make the indexes on a collection match a set of desired IndexModels. It
+// is returned by IndexView.Plan and is not applied to the collection.
+type IndexPlan struct {
+	// The desired indexes that do not exist on the collection. The Options.Name field of each model is set to the
+	// name that will be used to create the index.
+	Create []IndexModel
+
+	// The names of the indexes that exist on the collection but are not desired. The "_id_" index is never included.
+	Drop []string
+
+	// The desired indexes that exist on the collection with the same name but a different keys document or different
+	// options. These must be dropped and created again to match the desired state.
+	Recreate []IndexModel
+}
+
+// Empty returns true if the plan does not contain any changes.
+func (p *IndexPlan) Empty() bool {
+	return len(p.Create) == 0 && len(p.Drop) == 0 && len(p.Recreate) == 0
+}
+
+// IndexDiff describes how the indexes on a collection differ from a set of desired IndexModels. It is returned by
+// IndexView.DiffWith.
+type IndexDiff struct {
+	// The desired indexes that do not exist on the collection. The Options.Name field of each model is set to the
+	// name that would be used to create the index.
+	Add []IndexModel
+
+	// The indexes that exist on the collection but are not desired. The "_id_" index is never included.
+	Remove []*IndexSpecification
+
+	// The desired indexes that exist on the collection with the same name but a different keys document or different
+	// options.
+	Change []IndexChange
+}
+
+// IndexChange is an index that exists on a collection with the same name as a desired IndexModel but does not match
+// it.
+type IndexChange struct {
+	// The desired index. The Options.Name field is set to the name of the existing index.
+	Desired IndexModel
+
+	// The specification of the existing index.
+	Existing *IndexSpecification
+}
+
+// Empty returns true if the diff does not contain any differences.
+func (d *IndexDiff) Empty() bool {
+	return len(d.Add) == 0 && len(d.Remove) == 0 && len(d.Change) == 0
+}
+
+// DiffWith compares the desired IndexModels with the indexes that exist on the collection and returns the indexes to
+// add, remove, and change. Indexes are matched by name. If a model does not specify a name, the name is generated from
+// its Keys document as in IndexView.CreateMany. An existing index is changed if its keys or any option set on the
+// desired model differ, or if it sets an option that changes its behavior, such as unique, that the model does not.
+//
+// DiffWith only reads the existing indexes. IndexView.Reconcile can be used to apply the differences.
+func (iv IndexView) DiffWith(ctx context.Context, desired []IndexModel) (*IndexDiff, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	wanted, err := iv.desiredIndexes(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := iv.listRawSpecs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	existingByName := make(map[string]bson.Raw, len(existing))
+	for _, spec := range existing {
+		name, _ := spec.Lookup("name").StringValueOK()
+		existingByName[name] = spec
+	}
+
+	diff := &IndexDiff{}
+	wantedNames := make(map[string]struct{}, len(wanted))
+	for _, d := range wanted {
+		wantedNames[d.name] = struct{}{}
+
+		raw, ok := existingByName[d.name]
+		switch {
+		case !ok:
+			diff.Add = append(diff.Add, d.model)
+		case !d.matchesSpec(raw):
+			spec := &IndexSpecification{}
+			if err := bson.Unmarshal(raw, spec); err != nil {
+				return nil, err
+			}
+			diff.Change = append(diff.Change, IndexChange{Desired: d.model, Existing: spec})
+		}
+	}
+
+	for _, raw := range existing {
+		name, _ := raw.Lookup("name").StringValueOK()
+		if _, ok := wantedNames[name]; ok || name == "_id_" {
+			continue
+		}
+		spec := &IndexSpecification{}
+		if err := bson.Unmarshal(raw, spec); err != nil {
+			return nil, err
+		}
+		diff.Remove = append(diff.Remove, spec)
+	}
+
+	return diff, nil
+}
+
+// Plan compares the desired IndexModels with the indexes that exist on the collection and returns the changes needed
+// to make them match without applying them. Indexes are matched by name. If a model does not specify a name, the name
+// is generated from its Keys document as in IndexView.CreateMany.
+//
+// The opts parameter can be used to specify options for this operation (see the options.PlanIndexesOptions
+// documentation).
+func (iv IndexView) Plan(ctx context.Context, desired []IndexModel, opts ...*options.PlanIndexesOptions) (*IndexPlan, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	po := options.MergePlanIndexesOptions(opts...)
+
+	diff, err := iv.DiffWith(ctx, desired)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &IndexPlan{Create: diff.Add}
+	for _, change := range diff.Change {
+		plan.Recreate = append(plan.Recreate, change.Desired)
+	}
+	if po.KeepUnlisted == nil || !*po.KeepUnlisted {
+		for _, spec := range diff.Remove {
+			plan.Drop = append(plan.Drop, spec.Name)
+		}
+	}
+
+	return plan, nil
+}
+
+// Reconcile computes the changes needed to make the indexes on the collection match the desired IndexModels as in
+// IndexView.Plan and applies them one index at a time. Indexes in the plan's Drop and Recreate lists are dropped first,
+// and then the indexes in the Create and Recreate lists are created. The applied plan is returned.
+//
+// If a step fails or the context is cancelled, Reconcile stops and returns the plan along with the error. The steps
+// performed before the failure are not rolled back.
+//
+// The opts parameter can be used to specify options for this operation (see the options.ReconcileIndexesOptions
+// documentation).
+func (iv IndexView) Reconcile(
+	ctx context.Context,
+	desired []IndexModel,
+	opts ...*options.ReconcileIndexesOptions,
+) (*IndexPlan, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ro := options.MergeReconcileIndexesOptions(opts...)
+	po := options.PlanIndexes()
+	if ro.KeepUnlisted != nil {
+		po.SetKeepUnlisted(*ro.KeepUnlisted)
+	}
+
+	plan, err := iv.Plan(ctx, desired, po)
+	if err != nil {
+		return nil, err
+	}
+
+	type step struct {
+		action string
+		name   string
+		model  IndexModel
+	}
+	steps := make([]step, 0, len(plan.Drop)+len(plan.Create)+2*len(plan.Recreate))
+	for _, name := range plan.Drop {
+		steps = append(steps, step{action: "drop", name: name})
+	}
+	for _, model := range plan.Recreate {
+		steps = append(steps, step{action: "drop", name: *model.Options.Name})
+	}
+	for _, model := range append(append([]IndexModel(nil), plan.Create...), plan.Recreate...) {
+		steps = append(steps, step{action: "create", name: *model.Options.Name, model: model})
+	}
+
+	report := func(evt options.IndexProgressEvent) {
+		if ro.ProgressFn != nil && ctx.Err() == nil {
+			ro.ProgressFn(evt)
+		}
+	}
+	for i, s := range steps {
+		evt := options.IndexProgressEvent{Action: s.action, Name: s.name, Step: i + 1, Total: len(steps)}
+		report(evt)
+		if err := ctx.Err(); err != nil {
+			return plan, err
+		}
+
+		if s.action == "drop" {
+			_, err = iv.DropOne(ctx, s.name)
+		} else {
+			_, err = iv.CreateOne(ctx, s.model)
+		}
+
+		evt.Done = true
+		evt.Err = err
+		report(evt)
+		if err != nil {
+			return plan, fmt.Errorf("error during step %d of %d (%s %q): %w", i+1, len(steps), s.action, s.name, err)
+		}
+	}
+
+	return plan, nil
+}
+
+// desiredIndex is an IndexModel along with the marshalled documents that would be sent to the server to create it.
+type desiredIndex struct {
+	model   IndexModel
+	name    string
+	keys    bsoncore.Document
+	options bsoncore.Document
+}
+
+// desiredIndexes marshals the given models and resolves their names. The Options of the returned models are copies
+// with the Name field set, so the caller's models are not modified. As in CreateMany, a model with the same keys and
+// options as an earlier one is dropped, and a model that reuses an earlier name with different keys or options is an
+// error.
+func (iv IndexView) desiredIndexes(models []IndexModel) ([]desiredIndex, error) {
+	indexes := make([]desiredIndex, 0, len(models))
+	seen := make(map[string]int, len(models))
+
+	for _, model := range models {
+		if model.Keys == nil {
+			return nil, fmt.Errorf("index model keys cannot be nil")
+		}
+
+		if isUnorderedMap(model.Keys) {
+			return nil, ErrMapForOrderedArgument{"keys"}
+		}
+
+		keys, err := marshal(model.Keys, iv.coll.bsonOpts, iv.coll.registry)
+		if err != nil {
+			return nil, err
+		}
+
+		name, err := getOrGenerateIndexName(keys, model)
+		if err != nil {
+			return nil, err
+		}
+
+		model.Options = options.MergeIndexOptions(model.Options).SetName(name)
+
+		optsDoc, err := iv.createOptionsDoc(model.Options)
+		if err != nil {
+			return nil, err
+		}
+
+		if prev, ok := seen[name]; ok {
+			if indexDocumentsEqual(keys, indexes[prev].keys) &&
+				indexDocumentsEqual(bsoncore.BuildDocument(nil, optsDoc), indexes[prev].options) {
+				continue
+			}
+			return nil, fmt.Errorf("multiple index models with name %q and different keys or options", name)
+		}
+		seen[name] = len(indexes)
+
+		indexes = append(indexes, desiredIndex{
+			model:   model,
+			name:    name,
+			keys:    keys,
+			options: bsoncore.BuildDocument(nil, optsDoc),
+		})
+	}
+
+	return indexes, nil
+}
+
+// listRawSpecs returns the raw index specification documents for the collection.
+func (iv IndexView) listRawSpecs(ctx context.Context) ([]bson.Raw, error) {
+	cursor, err := iv.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var specs []bson.Raw
+	if err := cursor.All(ctx, &specs); err != nil {
+		return nil, err
+	}
+	return specs, nil
+}
+
+// indexSignificantOptions are the index options that change the behavior of an index. If the server reports one of
+// these for an existing index but the desired model does not specify it, the index does not match the model.
+var indexSignificantOptions = []string{
+	"unique",
+	"sparse",
+	"hidden",
+	"expireAfterSeconds",
+	"partialFilterExpression",
+	"collation",
+	"wildcardProjection",
+}
+
+// matchesSpec returns true if the existing index specification has the same keys as the desired index and agrees
+// with every option set on it. Options that the server adds with default values are ignored.
+func (d desiredIndex) matchesSpec(spec bson.Raw) bool {
+	serverKeys, ok := spec.Lookup("key").DocumentOK()
+	if !ok || !indexDocumentsEqual(normalizeTextIndexKeys(d.keys), bsoncore.Document(serverKeys)) {
+		return false
+	}
+
+	elems, _ := d.options.Elements()
+	for _, elem := range elems {
+		key := elem.Key()
+		switch key {
+		case "name", "background":
+			continue
+		}
+
+		serverVal := bsoncore.Document(spec).Lookup(key)
+		switch key {
+		case "collation":
+			if serverVal.Type != bsontype.EmbeddedDocument || !indexDocumentContains(serverVal.Document(), elem.Value().Document()) {
+				return false
+			}
+		case "weights":
+			if serverVal.Type != bsontype.EmbeddedDocument || !indexWeightsEqual(serverVal.Document(), elem.Value().Document()) {
+				return false
+			}
+		default:
+			if !indexValuesEqual(elem.Value(), serverVal) {
+				return false
+			}
+		}
+	}
+
+	if d.options.Lookup("weights").Type == bsontype.Type(0) {
+		if implied := impliedTextIndexWeights(d.keys); implied != nil {
+			serverVal := bsoncore.Document(spec).Lookup("weights")
+			if serverVal.Type != bsontype.EmbeddedDocument || !indexWeightsEqual(serverVal.Document(), implied) {
+				return false
+			}
+		}
+	}
+
+	for _, key := range indexSignificantOptions {
+		if d.options.Lookup(key).Type != bsontype.Type(0) {
+			continue
+		}
+		serverVal := bsoncore.Document(spec).Lookup(key)
+		if serverVal.Type == bsontype.Type(0) || (serverVal.Type == bsontype.Boolean && !serverVal.Boolean()) {
+			continue
+		}
+		return false
+	}
+
+	return true
+}
+
+// normalizeTextIndexKeys returns keys with the fields of a text index replaced by the {_fts: "text", _ftsx: 1} pair
+// that the server reports in place of the first text field.
+func normalizeTextIndexKeys(keys bsoncore.Document) bsoncore.Document {
+	elems, err := keys.Elements()
+	if err != nil {
+		return keys
+	}
+
+	var hasText bool
+	idx, doc := bsoncore.AppendDocumentStart(nil)
+	for _, elem := range elems {
+		if str, ok := elem.Value().StringValueOK(); ok && str == "text" {
+			if !hasText {
+				doc = bsoncore.AppendStringElement(doc, "_fts", "text")
+				doc = bsoncore.AppendInt32Element(doc, "_ftsx", 1)
+				hasText = true
+			}
+			continue
+		}
+		doc = bsoncore.AppendValueElement(doc, elem.Key(), elem.Value())
+	}
+	if !hasText {
+		return keys
+	}
+
+	doc, _ = bsoncore.AppendDocumentEnd(doc, idx)
+	return doc
+}
+
+// impliedTextIndexWeights returns the weights document the server creates for a text index that does not specify
+// weights, which gives each text field a weight of 1. If keys does not contain any text fields, nil is returned.
+func impliedTextIndexWeights(keys bsoncore.Document) bsoncore.Document {
+	elems, err := keys.Elements()
+	if err != nil {
+		return nil
+	}
+
+	var weights []byte
+	for _, elem := range elems {
+		if str, ok := elem.Value().StringValueOK(); ok && str == "text" {
+			weights = bsoncore.AppendInt32Element(weights, elem.Key(), 1)
+		}
+	}
+	if weights == nil {
+		return nil
+	}
+	return bsoncore.BuildDocument(nil, weights)
+}
+
+// indexValuesEqual compares two BSON values, treating all numeric types as equal if they have the same value and
+// comparing embedded documents and arrays element by element. A Boolean false is equal to a missing value.
+func indexValuesEqual(a, b bsoncore.Value) bool {
+	if af, ok := indexNumber(a); ok {
+		bf, ok := indexNumber(b)
+		return ok && af == bf
+	}
+	if a.Type == bsontype.Boolean && b.Type == bsontype.Type(0) {
+		return !a.Boolean()
+	}
+	if a.Type != b.Type {
+		return false
+	}
+
+	switch a.Type {
+	case bsontype.EmbeddedDocument:
+		return indexDocumentsEqual(a.Document(), b.Document())
+	case bsontype.Array:
+		return indexDocumentsEqual(bsoncore.Document(a.Array()), bsoncore.Document(b.Array()))
+	default:
+		return bytes.Equal(a.Data, b.Data)
+	}
+}
+
+// indexNumber returns the value of an int32, int64, or double BSON value as a float64.
+func indexNumber(v bsoncore.Value) (float64, bool) {
+	switch v.Type {
+	case bsontype.Int32:
+		return float64(v.Int32()), true
+	case bsontype.Int64:
+		return float64(v.Int64()), true
+	case bsontype.Double:
+		return v.Double(), true
+	default:
+		return 0, false
+	}
+}
+
+// indexDocumentsEqual returns true if a and b have the same keys in the same order with equal values.
+func indexDocumentsEqual(a, b bsoncore.Document) bool {
+	aElems, err := a.Elements()
+	if err != nil {
+		return false
+	}
+	bElems, err := b.Elements()
+	if err != nil {
+		return false
+	}
+	if len(aElems) != len(bElems) {
+		return false
+	}
+
+	for i := range aElems {
+		if aElems[i].Key() != bElems[i].Key() || !indexValuesEqual(aElems[i].Value(), bElems[i].Value()) {
+			return false
+		}
+	}
+	return true
+}
+
+// indexDocumentContains returns true if every element in subset has an equal value in doc.
+func indexDocumentContains(doc, subset bsoncore.Document) bool {
+	elems, err := subset.Elements()
+	if err != nil {
+		return false
+	}
+
+	for _, elem := range elems {
+		if !indexValuesEqual(elem.Value(), doc.Lookup(elem.Key())) {
+			return false
+		}
+	}
+	return true
+}
+
+// indexWeightsEqual compares two text index weights documents, which the server does not return in a stable order.
+func indexWeightsEqual(a, b bsoncore.Document) bool {
+	aElems, err := a.Elements()
+	if err != nil {
+		return false
+	}
+	bElems, err := b.Elements()
+	if err != nil {
+		return false
+	}
+
+	return len(aElems) == len(bElems) && indexDocumentContains(a, b)
+}
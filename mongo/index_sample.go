@@ -0,0 +1,234 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// IndexSampleMismatchError is returned by ValidateAgainstSample when the keys of an index model do not fit a sample
+// document. Missing fields are often harmless because documents in a collection can have different shapes, so
+// callers that only care about type mismatches can inspect Incompatible and ignore Missing.
+type IndexSampleMismatchError struct {
+	// The key fields that are not present in the sample document, in key order.
+	Missing []string
+
+	// The reason the sample's value for each key field cannot be indexed with the key's index type, keyed by field.
+	Incompatible map[string]error
+}
+
+// Error implements the error interface.
+func (e IndexSampleMismatchError) Error() string {
+	var problems []string
+	if len(e.Missing) > 0 {
+		problems = append(problems, fmt.Sprintf("fields missing from sample: %s", strings.Join(e.Missing, ", ")))
+	}
+
+	fields := make([]string, 0, len(e.Incompatible))
+	for field := range e.Incompatible {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		problems = append(problems, fmt.Sprintf("field %q: %v", field, e.Incompatible[field]))
+	}
+	return "index keys do not match sample document: " + strings.Join(problems, "; ")
+}
+
+// ValidateAgainstSample checks the keys of model against a sample document from the collection the index is intended
+// for. It reports key fields that are not present in the sample, which usually indicates a typo, and key fields whose
+// sample value cannot be indexed with the key's index type, such as a "2dsphere" key on a string field. Dotted key
+// paths descend into embedded documents and arrays of documents. For wildcard keys, only the prefix before "$**" must
+// be present.
+//
+// This is a client-side heuristic and does not contact the server. A nil error does not guarantee that the server will
+// accept the index or every document in the collection. If the keys do not fit the sample, an
+// IndexSampleMismatchError is returned.
+func ValidateAgainstSample(model IndexModel, sample bson.D) error {
+	keys, err := marshal(model.Keys, nil, nil)
+	if err != nil {
+		return err
+	}
+	if sample == nil {
+		sample = bson.D{}
+	}
+	doc, err := marshal(sample, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	elems, err := keys.Elements()
+	if err != nil {
+		return err
+	}
+	if len(elems) == 0 {
+		return errors.New("index keys must contain at least one field")
+	}
+
+	root := bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: doc}
+	var mismatch IndexSampleMismatchError
+	for _, elem := range elems {
+		field := elem.Key()
+		if field == "$**" {
+			continue
+		}
+		path := strings.TrimSuffix(field, ".$**")
+
+		values := sampleValues(root, strings.Split(path, "."))
+		if len(values) == 0 {
+			mismatch.Missing = append(mismatch.Missing, field)
+			continue
+		}
+
+		indexType, ok := elem.Value().StringValueOK()
+		if !ok {
+			continue
+		}
+		for _, val := range values {
+			if err := checkSampleValue(indexType, val); err != nil {
+				if mismatch.Incompatible == nil {
+					mismatch.Incompatible = make(map[string]error)
+				}
+				mismatch.Incompatible[field] = err
+				break
+			}
+		}
+	}
+
+	if len(mismatch.Missing) == 0 && len(mismatch.Incompatible) == 0 {
+		return nil
+	}
+	return mismatch
+}
+
+// sampleValues returns the values at the dotted path in val. Like the server does when generating multikey index
+// keys, arrays are traversed element by element, and a numeric path component may also select an array element.
+func sampleValues(val bsoncore.Value, path []string) []bsoncore.Value {
+	if len(path) == 0 {
+		return []bsoncore.Value{val}
+	}
+
+	switch val.Type {
+	case bsontype.EmbeddedDocument:
+		next, err := val.Document().LookupErr(path[0])
+		if err != nil {
+			return nil
+		}
+		return sampleValues(next, path[1:])
+	case bsontype.Array:
+		var values []bsoncore.Value
+		if next, err := bsoncore.Document(val.Array()).LookupErr(path[0]); err == nil {
+			values = append(values, sampleValues(next, path[1:])...)
+		}
+		elems, err := val.Array().Values()
+		if err != nil {
+			return values
+		}
+		for _, elem := range elems {
+			if elem.Type == bsontype.EmbeddedDocument {
+				values = append(values, sampleValues(elem, path)...)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// checkSampleValue returns an error if val cannot be indexed by a key with the given string index type. Unknown index
+// types are not checked.
+func checkSampleValue(indexType string, val bsoncore.Value) error {
+	switch indexType {
+	case "2dsphere":
+		if isGeoJSON(val) || isLegacyCoordinatePair(val) {
+			return nil
+		}
+		if val.Type == bsontype.Array {
+			elems, _ := val.Array().Values()
+			for _, elem := range elems {
+				if !isGeoJSON(elem) && !isLegacyCoordinatePair(elem) {
+					return fmt.Errorf("2dsphere keys require GeoJSON objects or coordinate pairs, got an array "+
+						"containing %s", elem.Type)
+				}
+			}
+			return nil
+		}
+		return fmt.Errorf("2dsphere keys require a GeoJSON object or coordinate pair, got %s", val.Type)
+	case "2d", "geoHaystack":
+		if isLegacyCoordinatePair(val) {
+			return nil
+		}
+		return fmt.Errorf("%s keys require a legacy coordinate pair, got %s", indexType, val.Type)
+	case "text":
+		if val.Type == bsontype.String {
+			return nil
+		}
+		if val.Type == bsontype.Array {
+			elems, _ := val.Array().Values()
+			for _, elem := range elems {
+				if elem.Type != bsontype.String {
+					return fmt.Errorf("text keys require strings, got an array containing %s", elem.Type)
+				}
+			}
+			return nil
+		}
+		return fmt.Errorf("text keys require a string or an array of strings, got %s", val.Type)
+	case "hashed":
+		if val.Type == bsontype.Array {
+			return errors.New("hashed keys cannot index arrays")
+		}
+	}
+	return nil
+}
+
+// isGeoJSON reports whether val is shaped like a GeoJSON object: an embedded document with a string "type" field and
+// either a "coordinates" array or, for a GeometryCollection, a "geometries" array.
+func isGeoJSON(val bsoncore.Value) bool {
+	if val.Type != bsontype.EmbeddedDocument {
+		return false
+	}
+	doc := val.Document()
+	if typ, err := doc.LookupErr("type"); err != nil || typ.Type != bsontype.String {
+		return false
+	}
+	if coords, err := doc.LookupErr("coordinates"); err == nil && coords.Type == bsontype.Array {
+		return true
+	}
+	geometries, err := doc.LookupErr("geometries")
+	return err == nil && geometries.Type == bsontype.Array
+}
+
+// isLegacyCoordinatePair reports whether val is a legacy coordinate pair: an array or embedded document whose first two
+// values are numbers.
+func isLegacyCoordinatePair(val bsoncore.Value) bool {
+	var values []bsoncore.Value
+	switch val.Type {
+	case bsontype.Array:
+		values, _ = val.Array().Values()
+	case bsontype.EmbeddedDocument:
+		values, _ = val.Document().Values()
+	default:
+		return false
+	}
+	if len(values) < 2 {
+		return false
+	}
+	for _, v := range values[:2] {
+		switch v.Type {
+		case bsontype.Double, bsontype.Int32, bsontype.Int64, bsontype.Decimal128:
+		default:
+			return false
+		}
+	}
+	return true
+}
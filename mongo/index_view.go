@@ -11,10 +11,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/internal/csot"
 	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
@@ -35,6 +41,25 @@ var ErrNonStringIndexName = errors.New("index name must be a string")
 // ErrMultipleIndexDrop is returned if multiple indexes would be dropped from a call to IndexView.DropOne.
 var ErrMultipleIndexDrop = errors.New("multiple indexes would be dropped")
 
+// ErrIndexNotFound is returned by IndexView.GetByName if the collection does not have an index with the given name.
+var ErrIndexNotFound = errors.New("index not found")
+
+// ErrIndexOnView is returned by IndexView.CreateOne and IndexView.CreateMany if the namespace is a view. Indexes cannot
+// be created on views. If the ValidateCollectionType or RequireExistingCollection option is set, the error is returned
+// before the createIndexes command is sent. Otherwise, the server's CommandNotSupportedOnView (166) error is returned
+// wrapped in an error that matches ErrIndexOnView with errors.Is.
+var ErrIndexOnView = errors.New("cannot create index on a view")
+
+// ErrNamespaceNotFound is returned by IndexView.CreateOne and IndexView.CreateMany if the
+// RequireExistingCollection option is set and the collection does not exist.
+var ErrNamespaceNotFound = errors.New("namespace not found")
+
+// ErrShardRequiresDirectConnection is returned by IndexView.CreateOne and IndexView.CreateMany if the Shard option is
+// set but the client is not connected directly to replica set members, for example because it is connected through
+// mongos.
+var ErrShardRequiresDirectConnection = errors.New("the Shard option requires a direct connection to the shard's " +
+	"replica set members rather than a connection through mongos")
+
 // IndexView is a type that can be used to create, drop, and list indexes on a collection. An IndexView for a collection
 // can be created by a call to Collection.Indexes().
 type IndexView struct {
@@ -52,236 +77,1634 @@ type IndexModel struct {
 	Options *options.IndexOptions
 }
 
-func isNamespaceNotFoundError(err error) bool {
-	if de, ok := err.(driver.Error); ok {
-		return de.Code == 26
+// IndexModification represents changes to an existing index that can be applied with a single collMod command. Fields
+// that are nil are not changed. At least one field must be set.
+type IndexModification struct {
+	// The new expireAfterSeconds value for a TTL index.
+	ExpireAfterSeconds *int32
+
+	// Whether the index should be hidden from the query planner. This is only valid for MongoDB versions >= 4.4.
+	Hidden *bool
+
+	// If true, the index will reject new duplicate keys so that it can later be converted to a unique index. This is
+	// only valid for MongoDB versions >= 6.0.
+	PrepareUnique *bool
+
+	// If true, the index will be converted to a unique index. The index must have PrepareUnique set and the collection
+	// must not contain duplicate keys. This is only valid for MongoDB versions >= 6.0.
+	Unique *bool
+}
+
+// Server error codes returned by index operations.
+const (
+	errCodeNamespaceNotFound                  int32 = 26
+	errCodeIndexNotFound                      int32 = 27
+	errCodeIndexOptionsConflict               int32 = 85
+	errCodeIndexKeySpecsConflict              int32 = 86
+	errCodeCommandNotSupportedOnView          int32 = 166
+	errCodeOperationNotSupportedInTransaction int32 = 263
+	errCodeCannotConvertIndexToUnique         int32 = 359
+
+	errCodeBackgroundOperationInProgressForDatabase  int32 = 12586
+	errCodeBackgroundOperationInProgressForNamespace int32 = 12587
+)
+
+// Retry settings for createIndexes commands that fail because a background operation is running on the namespace.
+const (
+	backgroundOperationMaxAttempts    = 5
+	backgroundOperationInitialBackoff = 100 * time.Millisecond
+)
+
+// hasIndexErrorCode returns true if err is a driver.Error or a ServerError with the given code.
+func hasIndexErrorCode(err error, code int32) bool {
+	var de driver.Error
+	if errors.As(err, &de) {
+		return de.Code == code
+	}
+	if se := ServerError(nil); errors.As(err, &se) {
+		return se.HasErrorCode(int(code))
+	}
+	return false
+}
+
+// IsNamespaceNotFoundError returns true if err is a NamespaceNotFound (26) error, which is returned when the target
+// collection or database does not exist.
+func IsNamespaceNotFoundError(err error) bool {
+	return hasIndexErrorCode(err, errCodeNamespaceNotFound)
+}
+
+// IsIndexNotFoundError returns true if err is an IndexNotFound (27) error, which is returned when dropping or
+// modifying an index that does not exist.
+func IsIndexNotFoundError(err error) bool {
+	return hasIndexErrorCode(err, errCodeIndexNotFound)
+}
+
+// IsIndexOptionsConflictError returns true if err is an IndexOptionsConflict (85) error, which is returned when an
+// index with the same keys but different options already exists.
+func IsIndexOptionsConflictError(err error) bool {
+	return hasIndexErrorCode(err, errCodeIndexOptionsConflict)
+}
+
+// IsIndexKeySpecsConflictError returns true if err is an IndexKeySpecsConflict (86) error, which is returned when an
+// index with the same name but different keys already exists.
+func IsIndexKeySpecsConflictError(err error) bool {
+	return hasIndexErrorCode(err, errCodeIndexKeySpecsConflict)
+}
+
+// IsBackgroundOperationInProgressError returns true if err is a BackgroundOperationInProgressForDatabase (12586) or
+// BackgroundOperationInProgressForNamespace (12587) error. These are transient errors that are returned when an index
+// build or another background operation is already running on the target database or collection.
+func IsBackgroundOperationInProgressError(err error) bool {
+	return hasIndexErrorCode(err, errCodeBackgroundOperationInProgressForDatabase) ||
+		hasIndexErrorCode(err, errCodeBackgroundOperationInProgressForNamespace)
+}
+
+// List executes a listIndexes command and returns a cursor over the indexes in the collection. The address of the
+// server that ran the command is available from Cursor.ServerAddress.
+//
+// The opts parameter can be used to specify options for this operation (see the options.ListIndexesOptions
+// documentation).
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/listIndexes/.
+func (iv IndexView) List(ctx context.Context, opts ...*options.ListIndexesOptions) (*Cursor, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	lio := options.MergeListIndexesOptions(opts...)
+	iv = iv.withCodecOverrides(lio.BSONOptions, lio.Registry)
+
+	sess := sessionFromContext(ctx)
+	if sess == nil && iv.coll.client.sessionPool != nil {
+		sess = session.NewImplicitClientSession(iv.coll.client.sessionPool, iv.coll.client.id)
+	}
+
+	err := iv.coll.client.validSession(sess)
+	if err != nil {
+		closeImplicitSession(sess)
+		return nil, err
+	}
+
+	// listIndexes only accepts the "local" read concern level, so other levels configured on the collection, such as
+	// "majority", are not sent.
+	rc := iv.coll.readConcern
+	if sess.TransactionRunning() || (rc != nil && rc.GetLevel() != "" && rc.GetLevel() != "local") {
+		rc = nil
+	}
+
+	selector := description.CompositeSelector([]description.ServerSelector{
+		description.ReadPrefSelector(readpref.Primary()),
+		description.LatencySelector(iv.coll.client.localThreshold),
+	})
+	selector = makeReadPrefSelector(sess, selector, iv.coll.client.localThreshold)
+
+	// TODO(GODRIVER-3038): This operation should pass CSE to the ListIndexes
+	// Crypt setter to be applied to the operation.
+	op := operation.NewListIndexes().
+		Session(sess).ReadConcern(rc).CommandMonitor(iv.coll.client.monitor).
+		ServerSelector(selector).ClusterClock(iv.coll.client.clock).
+		Database(iv.coll.db.name).Collection(iv.coll.name).
+		Deployment(iv.coll.client.deployment).ServerAPI(iv.coll.client.serverAPI).
+		Timeout(iv.coll.client.timeout)
+
+	cursorOpts := iv.coll.client.createBaseCursorOptions()
+
+	cursorOpts.MarshalValueEncoderFn = newEncoderFn(iv.coll.bsonOpts, iv.coll.registry)
+
+	if lio.BatchSize != nil {
+		op = op.BatchSize(*lio.BatchSize)
+		cursorOpts.BatchSize = *lio.BatchSize
+	}
+	if lio.Comment != nil {
+		comment, err := marshalValue(lio.Comment, iv.coll.bsonOpts, iv.coll.registry)
+		if err != nil {
+			closeImplicitSession(sess)
+			return nil, err
+		}
+
+		op = op.Comment(comment)
+	}
+	if lio.IncludeBuildInfo != nil {
+		op = op.IncludeBuildInfo(*lio.IncludeBuildInfo)
+	}
+	if lio.OperationName != nil {
+		op = op.OperationName(*lio.OperationName)
+	}
+	if lio.Timeout != nil {
+		op = op.Timeout(lio.Timeout)
+	}
+	op = op.MaxTime(lio.MaxTime)
+	retry := driver.RetryNone
+	if iv.coll.client.retryReads {
+		retry = driver.RetryOncePerCommand
+	}
+	op.Retry(retry)
+
+	err = op.Execute(ctx)
+	if err != nil {
+		// for namespaceNotFound errors, return an empty cursor and do not throw an error
+		closeImplicitSession(sess)
+		if IsNamespaceNotFoundError(err) {
+			return newEmptyCursor(), nil
+		}
+
+		return nil, replaceErrors(err)
+	}
+
+	bc, err := op.Result(cursorOpts)
+	if err != nil {
+		closeImplicitSession(sess)
+		return nil, replaceErrors(err)
+	}
+	cursor, err := newCursorWithSession(bc, iv.coll.bsonOpts, iv.coll.registry, sess)
+	return cursor, replaceErrors(err)
+}
+
+// withCodecOverrides returns an IndexView that uses the given BSON options and registry instead of the collection's.
+// A nil value keeps the collection's setting.
+func (iv IndexView) withCodecOverrides(bsonOpts *options.BSONOptions, registry *bsoncodec.Registry) IndexView {
+	if bsonOpts == nil && registry == nil {
+		return iv
+	}
+
+	coll := *iv.coll
+	if bsonOpts != nil {
+		coll.bsonOpts = bsonOpts
+	}
+	if registry != nil {
+		coll.registry = registry
+	}
+	return IndexView{coll: &coll}
+}
+
+// ListSpecifications executes a List command and returns a slice of returned IndexSpecifications
+func (iv IndexView) ListSpecifications(ctx context.Context, opts ...*options.ListIndexesOptions) ([]*IndexSpecification, error) {
+	cursor, err := iv.List(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*IndexSpecification
+	err = cursor.All(ctx, &results)
+	if err != nil {
+		return nil, err
+	}
+
+	ns := iv.coll.db.Name() + "." + iv.coll.Name()
+	for _, res := range results {
+		// Pre-4.4 servers report a namespace in their responses, so we only set Namespace manually if it was not in
+		// the response.
+		res.Namespace = ns
+	}
+
+	return results, nil
+}
+
+// GetByName executes a List command and returns the IndexSpecification for the index with the given name. If the
+// collection does not have an index with that name, ErrIndexNotFound is returned.
+func (iv IndexView) GetByName(ctx context.Context, name string, opts ...*options.ListIndexesOptions) (*IndexSpecification, error) {
+	specs, err := iv.ListSpecifications(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, spec := range specs {
+		if spec.Name == name {
+			return spec, nil
+		}
+	}
+	return nil, ErrIndexNotFound
+}
+
+// Count executes a List command and returns the number of indexes on the collection, including the "_id_" index. If
+// the collection does not exist, 0 is returned.
+func (iv IndexView) Count(ctx context.Context, opts ...*options.ListIndexesOptions) (int, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	cursor, err := iv.List(ctx, opts...)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	count := 0
+	for cursor.Next(ctx) {
+		count++
+	}
+	return count, cursor.Err()
+}
+
+// DefaultIDIndex returns the IndexSpecification for the "_id_" index that the server creates for every collection. If
+// the collection does not have an "_id_" index, as is the case for some system collections, ErrIndexNotFound is
+// returned.
+func (iv IndexView) DefaultIDIndex(ctx context.Context, opts ...*options.ListIndexesOptions) (*IndexSpecification, error) {
+	return iv.GetByName(ctx, "_id_", opts...)
+}
+
+// CompareTTL fetches the specification for the index with the given name and reports whether its expireAfterSeconds
+// value differs from desiredSeconds. The current value is also returned and is nil if the index is not a TTL index. If
+// the collection does not have an index with that name, ErrIndexNotFound is returned. A drifted TTL can be updated in
+// place with IndexView.SetTTL rather than dropping and recreating the index.
+func (iv IndexView) CompareTTL(ctx context.Context, name string, desiredSeconds int32) (bool, *int32, error) {
+	spec, err := iv.GetByName(ctx, name)
+	if err != nil {
+		return false, nil, err
+	}
+
+	current := spec.ExpireAfterSeconds
+	return current == nil || *current != desiredSeconds, current, nil
+}
+
+// SetTTL executes a collMod command to change the expireAfterSeconds value of the TTL index with the given name.
+func (iv IndexView) SetTTL(ctx context.Context, name string, seconds int32) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	cmd := bson.D{
+		{"collMod", iv.coll.name},
+		{"index", bson.D{
+			{"name", name},
+			{"expireAfterSeconds", seconds},
+		}},
+	}
+	return iv.coll.db.RunCommand(ctx, cmd).Err()
+}
+
+// Modify executes a collMod command to apply all of the changes in mod to the index with the given name. The server
+// applies the changes together, so either all of them or none of them take effect. The previous and new values
+// reported by the server are returned in an IndexModificationResult.
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/collMod/.
+func (iv IndexView) Modify(ctx context.Context, name string, mod IndexModification) (*IndexModificationResult, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if mod.ExpireAfterSeconds == nil && mod.Hidden == nil && mod.PrepareUnique == nil && mod.Unique == nil {
+		return nil, errors.New("index modification must set at least one field")
+	}
+
+	index := bson.D{{"name", name}}
+	if mod.ExpireAfterSeconds != nil {
+		index = append(index, bson.E{"expireAfterSeconds", *mod.ExpireAfterSeconds})
+	}
+	if mod.Hidden != nil {
+		index = append(index, bson.E{"hidden", *mod.Hidden})
+	}
+	if mod.PrepareUnique != nil {
+		index = append(index, bson.E{"prepareUnique", *mod.PrepareUnique})
+	}
+	if mod.Unique != nil {
+		index = append(index, bson.E{"unique", *mod.Unique})
+	}
+
+	cmd := bson.D{{"collMod", iv.coll.name}, {"index", index}}
+	var res IndexModificationResult
+	if err := iv.coll.db.RunCommand(ctx, cmd).Decode(&res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// ConvertToUniqueSafely converts the index with the given name to a unique index using the two-phase collMod workflow.
+// The index is first set to prepareUnique so that it rejects new duplicate keys, and then it is converted to a unique
+// index. If the conversion fails because the collection already contains duplicate keys, onDuplicates is called with
+// the violations reported by the server, which are documents in the form {ids: [<_id values>]}. If onDuplicates returns
+// nil, the conversion is retried once. If it returns an error, that error is returned. The index is left in the
+// prepareUnique state if the conversion does not succeed.
+//
+// This requires MongoDB version >= 6.0.
+func (iv IndexView) ConvertToUniqueSafely(
+	ctx context.Context,
+	name string,
+	onDuplicates func(violations []bson.Raw) error,
+) error {
+	prepare, unique := true, true
+	if _, err := iv.Modify(ctx, name, IndexModification{PrepareUnique: &prepare}); err != nil {
+		return err
+	}
+
+	_, err := iv.Modify(ctx, name, IndexModification{Unique: &unique})
+	violations, ok := uniqueViolations(err)
+	if !ok || onDuplicates == nil {
+		return err
+	}
+	if err := onDuplicates(violations); err != nil {
+		return err
+	}
+
+	_, err = iv.Modify(ctx, name, IndexModification{Unique: &unique})
+	return err
+}
+
+// Rebuild drops the index with the given name and recreates it with the same keys and options, for example to reclaim
+// storage. If the collection does not have an index with that name, ErrIndexNotFound is returned. The index is not
+// dropped if its specification contains options that cannot be recreated. If the index is dropped but recreating it
+// fails, an IndexRebuildError is returned.
+//
+// The opts parameter is passed to CreateOne when the index is recreated (see the options.CreateIndexesOptions
+// documentation).
+func (iv IndexView) Rebuild(ctx context.Context, name string, opts ...*options.CreateIndexesOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if name == "_id_" {
+		return errors.New("the _id_ index cannot be rebuilt")
+	}
+
+	specs, err := iv.listRawSpecs(ctx)
+	if err != nil {
+		return err
+	}
+
+	var model *IndexModel
+	for _, spec := range specs {
+		if specName, _ := spec.Lookup("name").StringValueOK(); specName != name {
+			continue
+		}
+		m, err := indexModelFromSpec(spec)
+		if err != nil {
+			return fmt.Errorf("index %q cannot be rebuilt: %w", name, err)
+		}
+		model = &m
+		break
+	}
+	if model == nil {
+		return ErrIndexNotFound
+	}
+
+	if _, err := iv.DropOne(ctx, name); err != nil {
+		return err
+	}
+	if _, err := iv.CreateOne(ctx, *model, opts...); err != nil {
+		return IndexRebuildError{Name: name, Model: *model, Wrapped: err}
+	}
+	return nil
+}
+
+// uniqueViolations returns the "violations" reported by the server in a CannotConvertIndexToUnique error.
+func uniqueViolations(err error) ([]bson.Raw, bool) {
+	var ce CommandError
+	if !errors.As(err, &ce) || ce.Code != errCodeCannotConvertIndexToUnique {
+		return nil, false
+	}
+
+	var reply struct {
+		Violations []bson.Raw `bson:"violations"`
+	}
+	if err := bson.Unmarshal(ce.Raw, &reply); err != nil {
+		return nil, false
+	}
+	return reply.Violations, true
+}
+
+// Usage runs an aggregation with a $indexStats stage and returns the usage statistics for each index on the collection.
+// An index with an Ops value of 0 has not been used since the statistics were last reset, which makes Usage useful for
+// finding indexes that can be dropped.
+//
+// The opts parameter can be used to specify options for the aggregation (see the options.AggregateOptions
+// documentation).
+func (iv IndexView) Usage(ctx context.Context, opts ...*options.AggregateOptions) ([]IndexUsage, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	cursor, err := iv.coll.Aggregate(ctx, Pipeline{{{"$indexStats", bson.D{}}}}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats []unmarshalIndexUsage
+	if err := cursor.All(ctx, &stats); err != nil {
+		return nil, err
+	}
+
+	usage := make([]IndexUsage, 0, len(stats))
+	for _, s := range stats {
+		usage = append(usage, IndexUsage{
+			Name:         s.Name,
+			KeysDocument: s.KeysDocument,
+			Host:         s.Host,
+			Ops:          s.Accesses.Ops,
+			Since:        s.Accesses.Since,
+		})
+	}
+	return usage, nil
+}
+
+// ExplainUsage runs the $indexStats aggregation used by IndexView.Usage under the explain command and returns the
+// query plan reported by the server.
+//
+// The opts parameter can be used to specify the same aggregation options that are passed to IndexView.Usage (see the
+// options.AggregateOptions documentation). MaxAwaitTime is ignored because explain does not return a cursor.
+func (iv IndexView) ExplainUsage(ctx context.Context, opts ...*options.AggregateOptions) (bson.Raw, error) {
+	return explainAggregate(ctx, iv.coll, Pipeline{{{"$indexStats", bson.D{}}}}, opts...)
+}
+
+// explainAggregate runs an aggregation with the given pipeline and options on coll under the explain command with the
+// "queryPlanner" verbosity and returns the server's reply.
+func explainAggregate(
+	ctx context.Context,
+	coll *Collection,
+	pipeline Pipeline,
+	opts ...*options.AggregateOptions,
+) (bson.Raw, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ao := options.MergeAggregateOptions(opts...)
+	cursor := bson.D{}
+	if ao.BatchSize != nil {
+		cursor = bson.D{{"batchSize", *ao.BatchSize}}
+	}
+	agg := bson.D{
+		{"aggregate", coll.name},
+		{"pipeline", pipeline},
+		{"cursor", cursor},
+	}
+	if ao.AllowDiskUse != nil {
+		agg = append(agg, bson.E{"allowDiskUse", *ao.AllowDiskUse})
+	}
+	if ao.BypassDocumentValidation != nil && *ao.BypassDocumentValidation {
+		agg = append(agg, bson.E{"bypassDocumentValidation", true})
+	}
+	if ao.Collation != nil {
+		agg = append(agg, bson.E{"collation", ao.Collation.ToDocument()})
+	}
+	if ao.MaxTime != nil {
+		agg = append(agg, bson.E{"maxTimeMS", int64(*ao.MaxTime / time.Millisecond)})
+	}
+	if ao.Comment != nil {
+		agg = append(agg, bson.E{"comment", *ao.Comment})
+	}
+	if ao.Hint != nil {
+		if isUnorderedMap(ao.Hint) {
+			return nil, ErrMapForOrderedArgument{"hint"}
+		}
+		agg = append(agg, bson.E{"hint", ao.Hint})
+	}
+	if ao.Let != nil {
+		agg = append(agg, bson.E{"let", ao.Let})
+	}
+	for name, val := range ao.Custom {
+		agg = append(agg, bson.E{name, val})
+	}
+
+	cmd := bson.D{
+		{"explain", agg},
+		{"verbosity", "queryPlanner"},
+	}
+	return coll.db.RunCommand(ctx, cmd).Raw()
+}
+
+// CreateOne executes a createIndexes command to create an index on the collection and returns the name of the new
+// index. See the IndexView.CreateMany documentation for more information and an example.
+func (iv IndexView) CreateOne(ctx context.Context, model IndexModel, opts ...*options.CreateIndexesOptions) (string, error) {
+	names, err := iv.CreateMany(ctx, []IndexModel{model}, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	return names[0], nil
+}
+
+// CreateMany executes a createIndexes command to create multiple indexes on the collection and returns the names of
+// the new indexes.
+//
+// For each IndexModel in the models parameter, the index name can be specified via the Options field. If a name is not
+// given, it will be generated from the Keys document.
+//
+// Models with the same keys and options as an earlier model are only sent once, but a name is still returned for every
+// model, so the returned slice has the same length and order as models. If two models have the same name but
+// different keys or options, an IndexModelError is returned for the later one without sending the command.
+//
+// If the command fails because a background operation is running on the collection or database, which can happen
+// transiently on sharded clusters, it is retried with exponential backoff a bounded number of times while the context
+// and the operation's timeout allow. The command is not retried in a transaction. IsBackgroundOperationInProgressError
+// can be used to detect the error if the retries are exhausted.
+//
+// The opts parameter can be used to specify options for this operation (see the options.CreateIndexesOptions
+// documentation).
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/createIndexes/.
+func (iv IndexView) CreateMany(ctx context.Context, models []IndexModel, opts ...*options.CreateIndexesOptions) ([]string, error) {
+	res, _, err := iv.createMany(ctx, models, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Names, nil
+}
+
+// CreateManyWithResult executes a createIndexes command in the same way as IndexView.CreateMany and returns a
+// CreateManyResult with the names of the new indexes and the server's reply. If the server reports a write concern
+// error, the indexes have been created but the write concern was not satisfied, so both the CreateManyResult and a
+// WriteException are returned.
+func (iv IndexView) CreateManyWithResult(
+	ctx context.Context,
+	models []IndexModel,
+	opts ...*options.CreateIndexesOptions,
+) (*CreateManyResult, error) {
+	res, _, err := iv.createMany(ctx, models, opts...)
+	return res, err
+}
+
+// CreateManyNamed executes a createIndexes command in the same way as IndexView.CreateMany and returns each of the
+// models paired with the name of its index, in the same order as the models parameter. Exact duplicate models are
+// paired with the same name. If the VerifyNames option is set, the names are those reported by the server.
+func (iv IndexView) CreateManyNamed(
+	ctx context.Context,
+	models []IndexModel,
+	opts ...*options.CreateIndexesOptions,
+) ([]NamedIndexModel, error) {
+	res, _, err := iv.createMany(ctx, models, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	named := make([]NamedIndexModel, 0, len(models))
+	for pos, model := range models {
+		named = append(named, NamedIndexModel{Model: model, Name: res.Names[pos]})
+	}
+	return named, nil
+}
+
+// CreateManyTimed executes a createIndexes command in the same way as IndexView.CreateMany and also returns the
+// wall-clock time spent executing the command, including any retries. Client-side validation and the listCollections
+// lookups done for the ValidateCollectionType and RequireExistingCollection options are not included. If the command
+// is not sent because of a client-side error, the returned duration is zero.
+func (iv IndexView) CreateManyTimed(
+	ctx context.Context,
+	models []IndexModel,
+	opts ...*options.CreateIndexesOptions,
+) ([]string, time.Duration, error) {
+	res, elapsed, err := iv.createMany(ctx, models, opts...)
+	if err != nil {
+		return nil, elapsed, err
+	}
+
+	return res.Names, elapsed, nil
+}
+
+func (iv IndexView) createMany(
+	ctx context.Context,
+	models []IndexModel,
+	opts ...*options.CreateIndexesOptions,
+) (*CreateManyResult, time.Duration, error) {
+	names := make([]string, 0, len(models))
+	keysDocs := make([]bsoncore.Document, 0, len(models))
+	optsDocs := make([]bsoncore.Document, 0, len(models))
+	option := options.MergeCreateIndexesOptions(opts...)
+	iv = iv.withCodecOverrides(option.BSONOptions, option.Registry)
+	foreground := option.Foreground != nil && *option.Foreground
+
+	var defaultStorageEngine bsoncore.Document
+	if option.DefaultStorageEngine != nil {
+		if isUnorderedMap(option.DefaultStorageEngine) {
+			return nil, 0, ErrMapForOrderedArgument{"storageEngine"}
+		}
+
+		doc, err := marshal(option.DefaultStorageEngine, iv.coll.bsonOpts, iv.coll.registry)
+		if err != nil {
+			return nil, 0, err
+		}
+		defaultStorageEngine = doc
+	}
+
+	batchSize := len(models)
+	if option.BatchSize != nil {
+		if *option.BatchSize <= 0 {
+			return nil, 0, fmt.Errorf("batch size must be greater than 0, got %d", *option.BatchSize)
+		}
+		if int(*option.BatchSize) < batchSize {
+			batchSize = int(*option.BatchSize)
+		}
+	}
+
+	if batchSize == 0 {
+		// An empty models slice is still sent in a single command so that the server reports the error.
+		batchSize = 1
+	}
+
+	indexDocs := make([]bsoncore.Document, 0, len(models))
+	// modelPositions holds the position in models of each index that is sent, which differs from its position in
+	// indexDocs once exact duplicates have been dropped.
+	modelPositions := make([]int, 0, len(models))
+	// namePositions holds the position in names of the index for each model.
+	namePositions := make([]int, 0, len(models))
+	for pos, model := range models {
+		if model.Keys == nil {
+			return nil, 0, fmt.Errorf("index model keys cannot be nil")
+		}
+
+		if isUnorderedMap(model.Keys) {
+			return nil, 0, ErrMapForOrderedArgument{"keys"}
+		}
+
+		keys, err := marshal(model.Keys, iv.coll.bsonOpts, iv.coll.registry)
+		if err != nil {
+			return nil, 0, err
+		}
+		if err := validateIndexKeyFields(keys); err != nil {
+			return nil, 0, err
+		}
+		if option.ValidateKeyValues != nil && *option.ValidateKeyValues {
+			if err := validateIndexKeyValues(keys); err != nil {
+				return nil, 0, err
+			}
+		}
+
+		name, err := getOrGenerateIndexName(keys, model)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if model.Options == nil {
+			model.Options = options.Index()
+		}
+		model.Options.SetName(name)
+
+		optsDoc, err := iv.createOptionsDoc(model.Options)
+		if err != nil {
+			return nil, 0, err
+		}
+		if defaultStorageEngine != nil && model.Options.StorageEngine == nil {
+			optsDoc = bsoncore.AppendDocumentElement(optsDoc, "storageEngine", defaultStorageEngine)
+		}
+		if foreground {
+			if model.Options.Background != nil && *model.Options.Background {
+				return nil, 0, fmt.Errorf("index %q cannot be built in the background when Foreground is set", name)
+			}
+			if model.Options.Background == nil {
+				optsDoc = bsoncore.AppendBooleanElement(optsDoc, "background", false)
+			}
+		}
+
+		if prev, identical := findIndexModel(name, keys, optsDoc, names, keysDocs, optsDocs); prev >= 0 {
+			if identical {
+				// Exact duplicates are only sent once so that the server does not reject the command.
+				namePositions = append(namePositions, prev)
+				continue
+			}
+			return nil, 0, IndexModelError{
+				Index:   pos,
+				Name:    name,
+				Wrapped: fmt.Errorf("index name is also used by models[%d] with different keys or options", modelPositions[prev]),
+			}
+		}
+
+		names = append(names, name)
+		keysDocs = append(keysDocs, keys)
+		optsDocs = append(optsDocs, optsDoc)
+		modelPositions = append(modelPositions, pos)
+		namePositions = append(namePositions, len(names)-1)
+
+		iidx, indexDoc := bsoncore.AppendDocumentStart(nil)
+		indexDoc = bsoncore.AppendDocumentElement(indexDoc, "key", keys)
+		indexDoc = append(indexDoc, optsDoc...)
+		indexDoc, err = bsoncore.AppendDocumentEnd(indexDoc, iidx)
+		if err != nil {
+			return nil, 0, err
+		}
+		indexDocs = append(indexDocs, indexDoc)
+	}
+
+	if option.MinServerVersion != nil {
+		if err := iv.checkServerVersion(ctx, *option.MinServerVersion); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	validateType := option.ValidateCollectionType != nil && *option.ValidateCollectionType
+	requireExisting := option.RequireExistingCollection != nil && *option.RequireExistingCollection
+	if validateType || requireExisting {
+		info, err := iv.collectionInfo(ctx)
+		if err != nil {
+			return nil, 0, err
+		}
+		if requireExisting && info.Name == "" {
+			return nil, 0, ErrNamespaceNotFound
+		}
+		if info.Type == "view" {
+			return nil, 0, ErrIndexOnView
+		}
+		if validateType && info.Options.TimeSeries != nil {
+			for i, keys := range keysDocs {
+				if err := validateTimeSeriesKeys(info.Options.TimeSeries, names[i], keys); err != nil {
+					// MongoDB 6.0 and later allow secondary indexes on measurement fields, so the restriction only
+					// applies to older servers. The version is only looked up when a key would be rejected.
+					verErr := iv.checkServerVersion(ctx, "6.0")
+					if verErr == nil {
+						break
+					}
+					var sve ServerVersionError
+					if !errors.As(verErr, &sve) {
+						return nil, 0, verErr
+					}
+					return nil, 0, err
+				}
+			}
+		}
+	}
+
+	sess := sessionFromContext(ctx)
+
+	if sess == nil && iv.coll.client.sessionPool != nil {
+		sess = session.NewImplicitClientSession(iv.coll.client.sessionPool, iv.coll.client.id)
+		defer sess.EndSession()
+	}
+
+	err := iv.coll.client.validSession(sess)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	wc := iv.coll.writeConcern
+	if option.WriteConcern != nil {
+		wc = option.WriteConcern
+	}
+	if sess.TransactionRunning() {
+		wc = nil
+	}
+	if !writeconcern.AckWrite(wc) {
+		sess = nil
+	}
+
+	writeSelector := iv.coll.writeSelector
+	if option.Shard != nil {
+		writeSelector = description.CompositeSelector([]description.ServerSelector{
+			shardSelector(*option.Shard),
+			writeSelector,
+		})
+	}
+	selector := makePinnedSelector(sess, writeSelector)
+
+	var commitQuorum bsoncore.Value
+	if option.CommitQuorum != nil {
+		if err := validateCommitQuorum(option.CommitQuorum); err != nil {
+			return nil, 0, err
+		}
+		commitQuorum, err = marshalValue(option.CommitQuorum, iv.coll.bsonOpts, iv.coll.registry)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	var auditComment bsoncore.Document
+	if option.AuditComment != nil {
+		auditComment, err = marshalAuditComment(option.AuditComment, iv.coll.bsonOpts, iv.coll.registry)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	order := indexBuildOrder(option.BuildOrder, keysDocs, optsDocs)
+	pick := func(positions []int) ([]string, []bsoncore.Document, []bsoncore.Document) {
+		batchNames := make([]string, 0, len(positions))
+		batchKeys := make([]bsoncore.Document, 0, len(positions))
+		batchOpts := make([]bsoncore.Document, 0, len(positions))
+		for _, pos := range positions {
+			batchNames = append(batchNames, names[pos])
+			batchKeys = append(batchKeys, keysDocs[pos])
+			batchOpts = append(batchOpts, optsDocs[pos])
+		}
+		return batchNames, batchKeys, batchOpts
+	}
+
+	var res *CreateManyResult
+	var elapsed time.Duration
+	for start := 0; start == 0 || start < len(order); start += batchSize {
+		end := start + batchSize
+		if end > len(order) {
+			end = len(order)
+		}
+
+		aidx, indexes := bsoncore.AppendArrayStart(nil)
+		for i, pos := range order[start:end] {
+			indexes = bsoncore.AppendDocumentElement(indexes, strconv.Itoa(i), indexDocs[pos])
+		}
+		indexes, err = bsoncore.AppendArrayEnd(indexes, aidx)
+		if err != nil {
+			return nil, elapsed, err
+		}
+
+		// TODO(GODRIVER-3038): This operation should pass CSE to the CreateIndexes
+		// Crypt setter to be applied to the operation.
+		//
+		// This was added in GODRIVER-2413 for the 2.0 major release.
+		op := operation.NewCreateIndexes(indexes).
+			Session(sess).WriteConcern(wc).ClusterClock(iv.coll.client.clock).
+			Database(iv.coll.db.name).Collection(iv.coll.name).CommandMonitor(iv.coll.client.monitor).
+			Deployment(iv.coll.client.deployment).ServerSelector(selector).ServerAPI(iv.coll.client.serverAPI).
+			Timeout(iv.coll.client.timeout).MaxTime(option.MaxTime)
+		if option.CommitQuorum != nil {
+			op.CommitQuorum(commitQuorum)
+		}
+		if foreground {
+			op.Foreground(true)
+		}
+		if auditComment != nil {
+			op.Comment(bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: auditComment})
+		}
+		if option.OperationName != nil {
+			op.OperationName(*option.OperationName)
+		}
+		if option.Timeout != nil {
+			op.Timeout(option.Timeout)
+		}
+
+		started := time.Now()
+		err = iv.executeCreateIndexes(ctx, op, sess, option.Timeout)
+		elapsed += time.Since(started)
+		if err != nil {
+			_, err = processWriteError(err)
+			var we WriteException
+			if errors.As(err, &we) && we.WriteConcernError != nil && len(we.WriteErrors) == 0 {
+				if end < len(order) {
+					// The indexes in this batch were created, but the remaining batches are not sent.
+					created, _, _ := pick(order[:end])
+					return nil, elapsed, PartialIndexCreationError{Created: created, Wrapped: err}
+				}
+				res = mergeCreateManyResult(res, names, op.Result())
+				res.Server = op.ServerDescription()
+				res.WriteConcernError = we.WriteConcernError
+				res.Names = modelIndexNames(names, namePositions)
+				return res, elapsed, err
+			}
+
+			batchNames, batchKeys, batchOpts := pick(order[start:end])
+			positions := make([]int, 0, end-start)
+			for _, pos := range order[start:end] {
+				positions = append(positions, modelPositions[pos])
+			}
+			err = createIndexesError(err, sess, batchNames, batchKeys, batchOpts, positions)
+			if start > 0 {
+				created, _, _ := pick(order[:start])
+				return nil, elapsed, PartialIndexCreationError{Created: created, Wrapped: err}
+			}
+			return nil, elapsed, err
+		}
+		res = mergeCreateManyResult(res, names, op.Result())
+		res.Server = op.ServerDescription()
+	}
+
+	if option.VerifyNames != nil && *option.VerifyNames {
+		specs, err := iv.listRawSpecs(ctx)
+		if err != nil {
+			return nil, elapsed, fmt.Errorf("error verifying index names: %w", err)
+		}
+		names = serverIndexNames(names, keysDocs, specs)
+	}
+	if option.VerifyHidden != nil && *option.VerifyHidden {
+		if err := iv.verifyHidden(ctx, names, optsDocs); err != nil {
+			return nil, elapsed, err
+		}
+	}
+
+	res.Names = modelIndexNames(names, namePositions)
+	return res, elapsed, nil
+}
+
+// modelIndexNames returns the name of the index for each model passed to createMany, given the names of the indexes
+// that were sent and the position in names of each model's index.
+func modelIndexNames(names []string, positions []int) []string {
+	byModel := make([]string, 0, len(positions))
+	for _, pos := range positions {
+		byModel = append(byModel, names[pos])
+	}
+	return byModel
+}
+
+// executeCreateIndexes executes op, retrying it while a background operation is in progress. If a timeout is set by
+// the timeout parameter or on the Client, it bounds all of the attempts together rather than each one.
+func (iv IndexView) executeCreateIndexes(
+	ctx context.Context,
+	op *operation.CreateIndexes,
+	sess *session.Client,
+	timeout *time.Duration,
+) error {
+	if timeout == nil {
+		timeout = iv.coll.client.timeout
+	}
+	if timeout != nil && !csot.IsTimeoutContext(ctx) {
+		var cancel context.CancelFunc
+		ctx, cancel = csot.MakeTimeoutContext(ctx, *timeout)
+		defer cancel()
+	}
+	return retryBackgroundOperationInProgress(ctx, sess, func() error { return op.Execute(ctx) })
+}
+
+// retryBackgroundOperationInProgress calls execute and retries it with exponential backoff while it fails with an
+// error for which IsBackgroundOperationInProgressError returns true, up to backgroundOperationMaxAttempts attempts.
+// Other errors are returned immediately. The last error is returned if the context is done or its deadline would pass
+// before the next attempt.
+//
+// execute is only called once if sess is running a transaction, because a failed command aborts the transaction and
+// a retry would fail with a NoSuchTransaction error that hides the original one.
+func retryBackgroundOperationInProgress(ctx context.Context, sess *session.Client, execute func() error) error {
+	if sess.TransactionRunning() {
+		return execute()
+	}
+
+	backoff := backgroundOperationInitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := execute()
+		if err == nil || attempt == backgroundOperationMaxAttempts || !IsBackgroundOperationInProgressError(err) {
+			return err
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < backoff {
+			return err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// checkServerVersion runs a buildInfo command and returns a ServerVersionError if the server's version is older than
+// minVersion.
+func (iv IndexView) checkServerVersion(ctx context.Context, minVersion string) error {
+	if _, err := parseServerVersion(minVersion); err != nil {
+		return fmt.Errorf("invalid MinServerVersion %q: %w", minVersion, err)
+	}
+
+	var info struct {
+		Version string `bson:"version"`
+	}
+	// buildInfo cannot run in a transaction, so it is run outside of any session in ctx.
+	lookupCtx := context.WithValue(ctx, sessionKey{}, nil)
+	err := iv.coll.client.Database("admin").RunCommand(lookupCtx, bson.D{{"buildInfo", 1}}).Decode(&info)
+	if err != nil {
+		return fmt.Errorf("error looking up server version: %w", err)
+	}
+
+	cmp, err := compareServerVersions(info.Version, minVersion)
+	if err != nil {
+		return fmt.Errorf("error parsing server version %q: %w", info.Version, err)
+	}
+	if cmp < 0 {
+		return ServerVersionError{MinVersion: minVersion, ServerVersion: info.Version}
+	}
+	return nil
+}
+
+// parseServerVersion parses a version in the form "major[.minor[.patch]]". A pre-release or build suffix starting with
+// "-" or "+", such as in "7.0.0-rc1", is ignored.
+func parseServerVersion(version string) ([]int, error) {
+	if idx := strings.IndexAny(version, "-+"); idx >= 0 {
+		version = version[:idx]
+	}
+
+	parts := strings.Split(version, ".")
+	nums := make([]int, 0, len(parts))
+	for _, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("version must be in the form \"major.minor.patch\", got %q", version)
+		}
+		nums = append(nums, n)
+	}
+	return nums, nil
+}
+
+// compareServerVersions returns a negative number if a is older than b, a positive number if a is newer than b, and 0
+// if they are the same. Missing components are treated as 0, so "4.4" and "4.4.0" are the same.
+func compareServerVersions(a, b string) (int, error) {
+	av, err := parseServerVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	bv, err := parseServerVersion(b)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := 0; i < len(av) || i < len(bv); i++ {
+		var x, y int
+		if i < len(av) {
+			x = av[i]
+		}
+		if i < len(bv) {
+			y = bv[i]
+		}
+		if x != y {
+			return x - y, nil
+		}
+	}
+	return 0, nil
+}
+
+// validateIndexKeyFields returns an error if keys has no fields or if a field name cannot be indexed. The server rejects
+// empty field names and path components that start with "$", except for a trailing "$**" wildcard component. "$natural"
+// is called out separately because it is a valid hint and is sometimes mistaken for an index key.
+func validateIndexKeyFields(keys bsoncore.Document) error {
+	elems, err := keys.Elements()
+	if err != nil {
+		return err
+	}
+	if len(elems) == 0 {
+		return errors.New("index keys must contain at least one field")
+	}
+
+	for _, elem := range elems {
+		field := elem.Key()
+		if field == "$natural" {
+			return errors.New("invalid index key field \"$natural\": $natural can be used as a hint but cannot be indexed")
+		}
+
+		parts := strings.Split(field, ".")
+		for i, part := range parts {
+			switch {
+			case part == "":
+				return fmt.Errorf("invalid index key field %q: field names and path components cannot be empty", field)
+			case part == "$**" && i == len(parts)-1:
+			case strings.HasPrefix(part, "$"):
+				return fmt.Errorf("invalid index key field %q: path components cannot start with \"$\" except for a "+
+					"trailing \"$**\" wildcard", field)
+			}
+		}
+	}
+	return nil
+}
+
+// indexKeyTypes are the string values accepted by validateIndexKeyValues.
+var indexKeyTypes = []string{"text", "hashed", "2d", "2dsphere", "geoHaystack"}
+
+// validateIndexKeyValues returns an error if the value of a field in keys is not a direction of 1 or -1 or one of the
+// index types in indexKeyTypes. getOrGenerateIndexName only checks the type of each value, so values such as 0 or a
+// misspelled index type would otherwise be sent and rejected by the server with a less specific error.
+func validateIndexKeyValues(keys bsoncore.Document) error {
+	elems, err := keys.Elements()
+	if err != nil {
+		return err
+	}
+
+	for _, elem := range elems {
+		val := elem.Value()
+		if str, ok := val.StringValueOK(); ok {
+			if !isIndexKeyType(str) {
+				return fmt.Errorf("invalid value %q for index key field %q: index types must be one of %q",
+					str, elem.Key(), indexKeyTypes)
+			}
+			continue
+		}
+
+		n, ok := indexNumber(val)
+		if !ok {
+			return fmt.Errorf("invalid %s value for index key field %q: values must be a direction or an index type",
+				val.Type, elem.Key())
+		}
+		if n != 1 && n != -1 {
+			return fmt.Errorf("invalid value %v for index key field %q: directions must be 1 or -1", n, elem.Key())
+		}
+	}
+	return nil
+}
+
+// isIndexKeyType returns true if typ is one of the index types in indexKeyTypes.
+func isIndexKeyType(typ string) bool {
+	for _, t := range indexKeyTypes {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// validateCommitQuorum returns an error if quorum is a plain string that differs from the "majority" or
+// "votingMembers" aliases only in case, which is almost certainly a typo. Other strings are tag names, and they, along
+// with numbers, options.CommitQuorumTag values, and other types, are left for the server to validate.
+func validateCommitQuorum(quorum interface{}) error {
+	str, ok := quorum.(string)
+	if !ok {
+		return nil
+	}
+	for _, alias := range []string{"majority", "votingMembers"} {
+		if str != alias && strings.EqualFold(str, alias) {
+			return fmt.Errorf("invalid commit quorum %q: did you mean %q? Use an options.CommitQuorumTag for a tag "+
+				"with this name", str, alias)
+		}
+	}
+	return nil
+}
+
+// mergeCreateManyResult combines the result of a createIndexes batch with the result of the previous batches. The
+// counts before the first batch and after the latest batch are kept, and the build statistics reported for each batch
+// are summed.
+func mergeCreateManyResult(prev *CreateManyResult, names []string, res operation.CreateIndexesResult) *CreateManyResult {
+	next := newCreateManyResult(names, res)
+	if prev != nil {
+		next.CreatedCollectionAutomatically = prev.CreatedCollectionAutomatically
+		next.IndexesBefore = prev.IndexesBefore
+		next.DocsScanned = sumBuildStat(prev.DocsScanned, next.DocsScanned)
+		next.KeysInserted = sumBuildStat(prev.KeysInserted, next.KeysInserted)
+	}
+	return next
+}
+
+// sumBuildStat adds two optional build statistics. The result is nil only if neither value was reported.
+func sumBuildStat(a, b *int64) *int64 {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	sum := *a + *b
+	return &sum
+}
+
+// findIndexModel returns the position in names of the index with the given name, or -1 if there is none, and whether
+// that index has the same keys and options. The options documents hold the elements of the options built by
+// createOptionsDoc, including the index name, so identical options imply the same name.
+func findIndexModel(
+	name string,
+	keys, optsDoc bsoncore.Document,
+	names []string,
+	keysDocs, optsDocs []bsoncore.Document,
+) (int, bool) {
+	for i, n := range names {
+		if n != name {
+			continue
+		}
+		return i, indexDocumentsEqual(keys, keysDocs[i]) &&
+			indexDocumentsEqual(bsoncore.BuildDocument(nil, optsDoc), bsoncore.BuildDocument(nil, optsDocs[i]))
+	}
+	return -1, false
+}
+
+// createIndexesError converts an error returned by a createIndexes command for a batch of models into a more specific
+// error. The names, keysDocs, and optsDocs slices describe the models in the batch, and positions holds the position of
+// each of them in the models passed to CreateMany.
+func createIndexesError(
+	err error,
+	sess *session.Client,
+	names []string,
+	keysDocs, optsDocs []bsoncore.Document,
+	positions []int,
+) error {
+	if isNotPrimaryIndexError(err) {
+		return notPrimaryIndexError(err)
+	}
+	if hasIndexErrorCode(err, errCodeCommandNotSupportedOnView) {
+		return indexOnViewError{wrapped: err}
+	}
+	if sess.TransactionRunning() && hasIndexErrorCode(err, errCodeOperationNotSupportedInTransaction) {
+		return fmt.Errorf("indexes can only be created in a transaction on a collection that does not "+
+			"exist or was created in the same transaction: %w", err)
+	}
+	if conflict, idx, ok := duplicateKeyConflict(err, keysDocs); ok {
+		if idx == -1 {
+			return conflict
+		}
+		filter := bsoncore.Document(bsoncore.BuildDocument(nil, optsDocs[idx])).Lookup("partialFilterExpression")
+		if doc, ok := filter.DocumentOK(); ok {
+			conflict.PartialFilterExpression = bson.Raw(doc)
+		}
+		return IndexModelError{Index: positions[idx], Name: names[idx], Wrapped: conflict}
+	}
+
+	if localeErr, idx, ok := invalidCollationLocale(err, optsDocs); ok {
+		if idx == -1 {
+			return localeErr
+		}
+		return IndexModelError{Index: positions[idx], Name: names[idx], Wrapped: localeErr}
+	}
+
+	err = correlateIndexError(err, names)
+	if modelErr, ok := err.(IndexModelError); ok {
+		modelErr.Index = positions[modelErr.Index]
+		return modelErr
 	}
-	return false
+	return err
 }
 
-// List executes a listIndexes command and returns a cursor over the indexes in the collection.
-//
-// The opts parameter can be used to specify options for this operation (see the options.ListIndexesOptions
-// documentation).
-//
-// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/listIndexes/.
-func (iv IndexView) List(ctx context.Context, opts ...*options.ListIndexesOptions) (*Cursor, error) {
-	if ctx == nil {
-		ctx = context.Background()
+// indexBuildOrder returns the positions of the models passed to CreateMany in the order in which their indexes should
+// be sent to the server.
+func indexBuildOrder(order options.IndexBuildOrder, keysDocs, optsDocs []bsoncore.Document) []int {
+	positions := make([]int, len(keysDocs))
+	for i := range positions {
+		positions[i] = i
 	}
-
-	sess := sessionFromContext(ctx)
-	if sess == nil && iv.coll.client.sessionPool != nil {
-		sess = session.NewImplicitClientSession(iv.coll.client.sessionPool, iv.coll.client.id)
+	if order != options.IndexBuildOrderByCost {
+		return positions
 	}
 
-	err := iv.coll.client.validSession(sess)
-	if err != nil {
-		closeImplicitSession(sess)
-		return nil, err
+	costs := make([]int, len(keysDocs))
+	for i := range keysDocs {
+		costs[i] = indexBuildCost(keysDocs[i], optsDocs[i])
 	}
-
-	selector := description.CompositeSelector([]description.ServerSelector{
-		description.ReadPrefSelector(readpref.Primary()),
-		description.LatencySelector(iv.coll.client.localThreshold),
+	sort.SliceStable(positions, func(i, j int) bool {
+		return costs[positions[i]] < costs[positions[j]]
 	})
-	selector = makeReadPrefSelector(sess, selector, iv.coll.client.localThreshold)
+	return positions
+}
 
-	// TODO(GODRIVER-3038): This operation should pass CSE to the ListIndexes
-	// Crypt setter to be applied to the operation.
-	op := operation.NewListIndexes().
-		Session(sess).CommandMonitor(iv.coll.client.monitor).
-		ServerSelector(selector).ClusterClock(iv.coll.client.clock).
-		Database(iv.coll.db.name).Collection(iv.coll.name).
-		Deployment(iv.coll.client.deployment).ServerAPI(iv.coll.client.serverAPI).
-		Timeout(iv.coll.client.timeout)
+// indexBuildCost returns a rough estimate of the relative cost of building an index. Each key field adds to the cost,
+// with text, wildcard, and geospatial keys weighted more heavily than ascending, descending, and hashed keys. Unique
+// indexes cost more because every key must be checked for duplicates.
+func indexBuildCost(keys, optsDoc bsoncore.Document) int {
+	elems, _ := keys.Elements()
 
-	cursorOpts := iv.coll.client.createBaseCursorOptions()
+	cost := 0
+	for _, elem := range elems {
+		switch typ, _ := elem.Value().StringValueOK(); {
+		case typ == "text":
+			cost += 4
+		case elem.Key() == "$**" || strings.HasSuffix(elem.Key(), ".$**"):
+			cost += 4
+		case typ == "2dsphere", typ == "2d", typ == "geoHaystack":
+			cost += 3
+		default:
+			cost++
+		}
+	}
 
-	cursorOpts.MarshalValueEncoderFn = newEncoderFn(iv.coll.bsonOpts, iv.coll.registry)
+	unique, _ := bsoncore.Document(bsoncore.BuildDocument(nil, optsDoc)).Lookup("unique").BooleanOK()
+	if unique {
+		cost++
+	}
+	return cost
+}
 
-	lio := options.MergeListIndexesOptions(opts...)
-	if lio.BatchSize != nil {
-		op = op.BatchSize(*lio.BatchSize)
-		cursorOpts.BatchSize = *lio.BatchSize
+// serverIndexNames returns the names the server reports for the indexes with the given client-generated names and
+// keys documents. An index keeps its name if a specification with that name exists. Otherwise, it takes the name of
+// the only specification with the same keys that no other index has claimed, or keeps its name if there is no such
+// specification.
+func serverIndexNames(names []string, keysDocs []bsoncore.Document, specs []bson.Raw) []string {
+	specNames := make([]string, len(specs))
+	claimed := make(map[string]bool, len(specs))
+	for i, spec := range specs {
+		specNames[i], _ = spec.Lookup("name").StringValueOK()
 	}
-	op = op.MaxTime(lio.MaxTime)
-	retry := driver.RetryNone
-	if iv.coll.client.retryReads {
-		retry = driver.RetryOncePerCommand
+	for _, name := range names {
+		for _, specName := range specNames {
+			if specName == name {
+				claimed[name] = true
+			}
+		}
 	}
-	op.Retry(retry)
 
-	err = op.Execute(ctx)
-	if err != nil {
-		// for namespaceNotFound errors, return an empty cursor and do not throw an error
-		closeImplicitSession(sess)
-		if isNamespaceNotFoundError(err) {
-			return newEmptyCursor(), nil
+	actual := make([]string, len(names))
+	for i, name := range names {
+		actual[i] = name
+		if claimed[name] {
+			continue
 		}
 
-		return nil, replaceErrors(err)
-	}
-
-	bc, err := op.Result(cursorOpts)
-	if err != nil {
-		closeImplicitSession(sess)
-		return nil, replaceErrors(err)
+		keys := normalizeTextIndexKeys(keysDocs[i])
+		match := ""
+		matches := 0
+		for j, spec := range specs {
+			serverKeys, ok := spec.Lookup("key").DocumentOK()
+			if !ok || claimed[specNames[j]] || !indexDocumentsEqual(keys, bsoncore.Document(serverKeys)) {
+				continue
+			}
+			match = specNames[j]
+			matches++
+		}
+		if matches == 1 {
+			actual[i] = match
+			claimed[match] = true
+		}
 	}
-	cursor, err := newCursorWithSession(bc, iv.coll.bsonOpts, iv.coll.registry, sess)
-	return cursor, replaceErrors(err)
+	return actual
 }
 
-// ListSpecifications executes a List command and returns a slice of returned IndexSpecifications
-func (iv IndexView) ListSpecifications(ctx context.Context, opts ...*options.ListIndexesOptions) ([]*IndexSpecification, error) {
-	cursor, err := iv.List(ctx, opts...)
-	if err != nil {
-		return nil, err
+// verifyHidden lists the indexes on the collection and returns an error if any of the named indexes whose options
+// document sets "hidden" to true is not reported as hidden by the server.
+func (iv IndexView) verifyHidden(ctx context.Context, names []string, optsDocs []bsoncore.Document) error {
+	var hidden []string
+	for i, optsDoc := range optsDocs {
+		if h, ok := bsoncore.Document(bsoncore.BuildDocument(nil, optsDoc)).Lookup("hidden").BooleanOK(); ok && h {
+			hidden = append(hidden, names[i])
+		}
+	}
+	if len(hidden) == 0 {
+		return nil
 	}
 
-	var results []*IndexSpecification
-	err = cursor.All(ctx, &results)
+	specs, err := iv.listRawSpecs(ctx)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("error verifying hidden indexes: %w", err)
 	}
+	applied := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		name, _ := spec.Lookup("name").StringValueOK()
+		h, _ := spec.Lookup("hidden").BooleanOK()
+		applied[name] = h
+	}
+	for _, name := range hidden {
+		if !applied[name] {
+			return fmt.Errorf("index %q was created but is not hidden; hidden indexes require MongoDB 4.4 or later", name)
+		}
+	}
+	return nil
+}
 
-	ns := iv.coll.db.Name() + "." + iv.coll.Name()
-	for _, res := range results {
-		// Pre-4.4 servers report a namespace in their responses, so we only set Namespace manually if it was not in
-		// the response.
-		res.Namespace = ns
+// isNotPrimaryIndexError returns true if err is a "not primary" error, which is returned when an index is created or
+// dropped on a server that is not the primary. This can only happen if the client is connected directly to a secondary,
+// because write operations are otherwise sent to the primary.
+func isNotPrimaryIndexError(err error) bool {
+	for _, code := range []int32{10107, 13435, 10058} {
+		if hasIndexErrorCode(err, code) {
+			return true
+		}
 	}
+	return false
+}
 
-	return results, nil
+// notPrimaryIndexError wraps a "not primary" error with an explanation of how to send index operations to the primary.
+func notPrimaryIndexError(err error) error {
+	return fmt.Errorf("index operations must be sent to the primary, but the selected server is not the primary; if "+
+		"the client uses a direct connection to a secondary, connect to the primary or the replica set instead: %w", err)
 }
 
-// CreateOne executes a createIndexes command to create an index on the collection and returns the name of the new
-// index. See the IndexView.CreateMany documentation for more information and an example.
-func (iv IndexView) CreateOne(ctx context.Context, model IndexModel, opts ...*options.CreateIndexesOptions) (string, error) {
-	names, err := iv.CreateMany(ctx, []IndexModel{model}, opts...)
-	if err != nil {
-		return "", err
-	}
+// indexOnViewError is returned by IndexView.CreateMany when the server rejects a createIndexes command because the
+// namespace is a view. It matches ErrIndexOnView with errors.Is and unwraps to the server's error.
+type indexOnViewError struct {
+	wrapped error
+}
 
-	return names[0], nil
+func (e indexOnViewError) Error() string {
+	return fmt.Sprintf("%v: %v", ErrIndexOnView, e.wrapped)
 }
 
-// CreateMany executes a createIndexes command to create multiple indexes on the collection and returns the names of
-// the new indexes.
-//
-// For each IndexModel in the models parameter, the index name can be specified via the Options field. If a name is not
-// given, it will be generated from the Keys document.
-//
-// The opts parameter can be used to specify options for this operation (see the options.CreateIndexesOptions
-// documentation).
-//
-// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/createIndexes/.
-func (iv IndexView) CreateMany(ctx context.Context, models []IndexModel, opts ...*options.CreateIndexesOptions) ([]string, error) {
-	names := make([]string, 0, len(models))
+func (e indexOnViewError) Is(target error) bool {
+	return target == ErrIndexOnView
+}
 
-	var indexes bsoncore.Document
-	aidx, indexes := bsoncore.AppendArrayStart(indexes)
+func (e indexOnViewError) Unwrap() error {
+	return e.wrapped
+}
 
-	for i, model := range models {
-		if model.Keys == nil {
-			return nil, fmt.Errorf("index model keys cannot be nil")
-		}
+// marshalAuditComment marshals the AuditComment option of a CreateIndexes operation and validates that it is a
+// non-empty document without operator field names.
+func marshalAuditComment(
+	val interface{},
+	bsonOpts *options.BSONOptions,
+	registry *bsoncodec.Registry,
+) (bsoncore.Document, error) {
+	if isUnorderedMap(val) {
+		return nil, ErrMapForOrderedArgument{"auditComment"}
+	}
 
-		if isUnorderedMap(model.Keys) {
-			return nil, ErrMapForOrderedArgument{"keys"}
-		}
+	doc, err := marshal(val, bsonOpts, registry)
+	if err != nil {
+		return nil, err
+	}
 
-		keys, err := marshal(model.Keys, iv.coll.bsonOpts, iv.coll.registry)
-		if err != nil {
-			return nil, err
+	elems, err := doc.Elements()
+	if err != nil {
+		return nil, err
+	}
+	if len(elems) == 0 {
+		return nil, errors.New("audit comment must be a non-empty document")
+	}
+	for _, elem := range elems {
+		if strings.HasPrefix(elem.Key(), "$") {
+			return nil, fmt.Errorf("audit comment field %q cannot start with '$'", elem.Key())
 		}
+	}
+	return doc, nil
+}
 
-		name, err := getOrGenerateIndexName(keys, model)
-		if err != nil {
-			return nil, err
+// shardSelector returns a ServerSelector that only selects members of the replica set with the given name, which is
+// the name of the shard when connected directly to a shard's members. It returns ErrShardRequiresDirectConnection
+// rather than waiting for the server selection timeout if the topology is sharded or none of the candidates is a
+// replica set member, because no server could ever be selected.
+func shardSelector(shard string) description.ServerSelector {
+	return description.ServerSelectorFunc(func(topo description.Topology, candidates []description.Server) ([]description.Server, error) {
+		if topo.Kind == description.Sharded {
+			return nil, ErrShardRequiresDirectConnection
 		}
 
-		names = append(names, name)
+		var servers []description.Server
+		replicaSetMember := false
+		for _, s := range candidates {
+			if s.SetName != "" {
+				replicaSetMember = true
+			}
+			if s.SetName == shard {
+				servers = append(servers, s)
+			}
+		}
+		if len(candidates) > 0 && !replicaSetMember {
+			return nil, ErrShardRequiresDirectConnection
+		}
+		return servers, nil
+	})
+}
 
-		var iidx int32
-		iidx, indexes = bsoncore.AppendDocumentElementStart(indexes, strconv.Itoa(i))
-		indexes = bsoncore.AppendDocumentElement(indexes, "key", keys)
+// indexCollectionInfo is the subset of a listCollections result used to validate index models against the type of
+// the target collection.
+type indexCollectionInfo struct {
+	Name    string `bson:"name"`
+	Type    string `bson:"type"`
+	Options struct {
+		TimeSeries *indexTimeSeriesInfo `bson:"timeseries"`
+	} `bson:"options"`
+}
 
-		if model.Options == nil {
-			model.Options = options.Index()
-		}
-		model.Options.SetName(name)
+type indexTimeSeriesInfo struct {
+	TimeField string `bson:"timeField"`
+	MetaField string `bson:"metaField"`
+}
 
-		optsDoc, err := iv.createOptionsDoc(model.Options)
-		if err != nil {
-			return nil, err
-		}
+// collectionInfo runs a listCollections command to look up the IndexView's collection. If the collection does not
+// exist, a zero indexCollectionInfo is returned. The result is not cached because the namespace can be dropped and
+// recreated with a different type at any time.
+func (iv IndexView) collectionInfo(ctx context.Context) (indexCollectionInfo, error) {
+	var info indexCollectionInfo
 
-		indexes = bsoncore.AppendDocument(indexes, optsDoc)
+	// listCollections cannot run in a transaction, so it is run outside of any session in ctx.
+	ctx = context.WithValue(ctx, sessionKey{}, nil)
+	cursor, err := iv.coll.db.ListCollections(ctx, bson.D{{"name", iv.coll.name}})
+	if err != nil {
+		return info, err
+	}
+	defer cursor.Close(ctx)
 
-		indexes, err = bsoncore.AppendDocumentEnd(indexes, iidx)
-		if err != nil {
-			return nil, err
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(&info); err != nil {
+			return info, err
 		}
 	}
+	if err := cursor.Err(); err != nil {
+		return info, err
+	}
+	return info, nil
+}
 
-	indexes, err := bsoncore.AppendArrayEnd(indexes, aidx)
+// validateTimeSeriesKeys returns an error if the keys document for the named index references a field other than the
+// time-series collection's timeField, metaField, or a subfield of the metaField.
+func validateTimeSeriesKeys(ts *indexTimeSeriesInfo, name string, keys bsoncore.Document) error {
+	elems, err := keys.Elements()
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	sess := sessionFromContext(ctx)
+	for _, elem := range elems {
+		field := elem.Key()
+		if field == ts.TimeField {
+			continue
+		}
+		if ts.MetaField != "" && (field == ts.MetaField || strings.HasPrefix(field, ts.MetaField+".")) {
+			continue
+		}
 
-	if sess == nil && iv.coll.client.sessionPool != nil {
-		sess = session.NewImplicitClientSession(iv.coll.client.sessionPool, iv.coll.client.id)
-		defer sess.EndSession()
+		if ts.MetaField == "" {
+			return fmt.Errorf("index %q cannot be created on a time-series collection: key %q is not the timeField %q",
+				name, field, ts.TimeField)
+		}
+		return fmt.Errorf("index %q cannot be created on a time-series collection: key %q is not the timeField %q "+
+			"or the metaField %q", name, field, ts.TimeField, ts.MetaField)
 	}
+	return nil
+}
 
-	err = iv.coll.client.validSession(sess)
-	if err != nil {
-		return nil, err
+// correlateIndexError attempts to attribute a createIndexes error to a single index model by searching the server's
+// error message for the quoted index names, which the server includes when it reports an invalid specification. If
+// exactly one model matches, the error is wrapped in an IndexModelError. Otherwise, err is returned unchanged.
+func correlateIndexError(err error, names []string) error {
+	var ce CommandError
+	if !errors.As(err, &ce) {
+		return err
 	}
 
-	wc := iv.coll.writeConcern
-	if sess.TransactionRunning() {
-		wc = nil
+	idx := -1
+	for i, name := range names {
+		if !strings.Contains(ce.Message, strconv.Quote(name)) {
+			continue
+		}
+		if idx != -1 {
+			return err
+		}
+		idx = i
 	}
-	if !writeconcern.AckWrite(wc) {
-		sess = nil
+	if idx == -1 {
+		return err
 	}
 
-	selector := makePinnedSelector(sess, iv.coll.writeSelector)
+	return IndexModelError{Index: idx, Name: names[idx], Wrapped: err}
+}
 
-	option := options.MergeCreateIndexesOptions(opts...)
+// invalidCollationLocale converts a BadValue error for an invalid collation locale into an
+// InvalidCollationLocaleError. The returned index is the position of the only model whose collation locale appears in
+// the error message, or -1 if no single model matches.
+func invalidCollationLocale(err error, optsDocs []bsoncore.Document) (InvalidCollationLocaleError, int, bool) {
+	var ce CommandError
+	if !errors.As(err, &ce) || ce.Code != 2 || !strings.Contains(ce.Message, "'locale' is invalid") {
+		return InvalidCollationLocaleError{}, -1, false
+	}
 
-	// TODO(GODRIVER-3038): This operation should pass CSE to the CreateIndexes
-	// Crypt setter to be applied to the operation.
-	//
-	// This was added in GODRIVER-2413 for the 2.0 major release.
-	op := operation.NewCreateIndexes(indexes).
-		Session(sess).WriteConcern(wc).ClusterClock(iv.coll.client.clock).
-		Database(iv.coll.db.name).Collection(iv.coll.name).CommandMonitor(iv.coll.client.monitor).
-		Deployment(iv.coll.client.deployment).ServerSelector(selector).ServerAPI(iv.coll.client.serverAPI).
-		Timeout(iv.coll.client.timeout).MaxTime(option.MaxTime)
-	if option.CommitQuorum != nil {
-		commitQuorum, err := marshalValue(option.CommitQuorum, iv.coll.bsonOpts, iv.coll.registry)
-		if err != nil {
-			return nil, err
+	localeErr := InvalidCollationLocaleError{Wrapped: err}
+	idx := -1
+	for i, optsDoc := range optsDocs {
+		doc := bsoncore.Document(bsoncore.BuildDocument(nil, optsDoc))
+		locale, ok := doc.Lookup("collation", "locale").StringValueOK()
+		if !ok || !strings.Contains(ce.Message, strconv.Quote(locale)) {
+			continue
+		}
+		if idx != -1 {
+			return InvalidCollationLocaleError{Wrapped: err}, -1, true
 		}
+		idx = i
+		localeErr.Locale = locale
+	}
+	return localeErr, idx, true
+}
 
-		op.CommitQuorum(commitQuorum)
+// duplicateKeyConflict converts a duplicate key error returned by createIndexes into a DuplicateKeyConflict using the
+// keyPattern and keyValue fields of the server's reply. The returned index is the position of the model whose keys
+// match the keyPattern, or -1 if no single model matches.
+func duplicateKeyConflict(err error, keysDocs []bsoncore.Document) (DuplicateKeyConflict, int, bool) {
+	var ce CommandError
+	if !errors.As(err, &ce) || ce.Code != 11000 {
+		return DuplicateKeyConflict{}, -1, false
 	}
 
-	err = op.Execute(ctx)
-	if err != nil {
-		_, err = processWriteError(err)
-		return nil, err
+	keyPattern, ok := ce.Raw.Lookup("keyPattern").DocumentOK()
+	if !ok {
+		return DuplicateKeyConflict{}, -1, false
+	}
+	conflict := DuplicateKeyConflict{KeyPattern: keyPattern, Wrapped: err}
+	if keyValue, ok := ce.Raw.Lookup("keyValue").DocumentOK(); ok {
+		conflict.KeyValue = keyValue
 	}
 
-	return names, nil
+	idx := -1
+	for i, keys := range keysDocs {
+		if !indexDocumentsEqual(keys, bsoncore.Document(keyPattern)) {
+			continue
+		}
+		if idx != -1 {
+			return conflict, -1, true
+		}
+		idx = i
+	}
+	return conflict, idx, true
 }
 
 func (iv IndexView) createOptionsDoc(opts *options.IndexOptions) (bsoncore.Document, error) {
+	return indexOptionsDoc(opts, iv.coll.bsonOpts, iv.coll.registry)
+}
+
+// indexOptionsDoc converts opts into the options fields of an index specification, marshaling document-valued options
+// with the given BSON options and registry.
+func indexOptionsDoc(
+	opts *options.IndexOptions,
+	bsonOpts *options.BSONOptions,
+	registry *bsoncodec.Registry,
+) (bsoncore.Document, error) {
 	optsDoc := bsoncore.Document{}
 	if opts.Background != nil {
 		optsDoc = bsoncore.AppendBooleanElement(optsDoc, "background", *opts.Background)
@@ -296,7 +1719,7 @@ func (iv IndexView) createOptionsDoc(opts *options.IndexOptions) (bsoncore.Docum
 		optsDoc = bsoncore.AppendBooleanElement(optsDoc, "sparse", *opts.Sparse)
 	}
 	if opts.StorageEngine != nil {
-		doc, err := marshal(opts.StorageEngine, iv.coll.bsonOpts, iv.coll.registry)
+		doc, err := marshal(opts.StorageEngine, bsonOpts, registry)
 		if err != nil {
 			return nil, err
 		}
@@ -307,6 +1730,9 @@ func (iv IndexView) createOptionsDoc(opts *options.IndexOptions) (bsoncore.Docum
 		optsDoc = bsoncore.AppendBooleanElement(optsDoc, "unique", *opts.Unique)
 	}
 	if opts.Version != nil {
+		if *opts.Version != 1 && *opts.Version != 2 {
+			return nil, fmt.Errorf("index version must be 1 or 2, got %d", *opts.Version)
+		}
 		optsDoc = bsoncore.AppendInt32Element(optsDoc, "v", *opts.Version)
 	}
 	if opts.DefaultLanguage != nil {
@@ -319,7 +1745,7 @@ func (iv IndexView) createOptionsDoc(opts *options.IndexOptions) (bsoncore.Docum
 		optsDoc = bsoncore.AppendInt32Element(optsDoc, "textIndexVersion", *opts.TextVersion)
 	}
 	if opts.Weights != nil {
-		doc, err := marshal(opts.Weights, iv.coll.bsonOpts, iv.coll.registry)
+		doc, err := marshal(opts.Weights, bsonOpts, registry)
 		if err != nil {
 			return nil, err
 		}
@@ -342,7 +1768,7 @@ func (iv IndexView) createOptionsDoc(opts *options.IndexOptions) (bsoncore.Docum
 		optsDoc = bsoncore.AppendInt32Element(optsDoc, "bucketSize", *opts.BucketSize)
 	}
 	if opts.PartialFilterExpression != nil {
-		doc, err := marshal(opts.PartialFilterExpression, iv.coll.bsonOpts, iv.coll.registry)
+		doc, err := marshal(opts.PartialFilterExpression, bsonOpts, registry)
 		if err != nil {
 			return nil, err
 		}
@@ -350,10 +1776,13 @@ func (iv IndexView) createOptionsDoc(opts *options.IndexOptions) (bsoncore.Docum
 		optsDoc = bsoncore.AppendDocumentElement(optsDoc, "partialFilterExpression", doc)
 	}
 	if opts.Collation != nil {
+		if opts.Collation.Locale == "" {
+			return nil, errors.New("index collation must specify a locale")
+		}
 		optsDoc = bsoncore.AppendDocumentElement(optsDoc, "collation", bsoncore.Document(opts.Collation.ToDocument()))
 	}
 	if opts.WildcardProjection != nil {
-		doc, err := marshal(opts.WildcardProjection, iv.coll.bsonOpts, iv.coll.registry)
+		doc, err := marshal(opts.WildcardProjection, bsonOpts, registry)
 		if err != nil {
 			return nil, err
 		}
@@ -368,6 +1797,23 @@ func (iv IndexView) createOptionsDoc(opts *options.IndexOptions) (bsoncore.Docum
 }
 
 func (iv IndexView) drop(ctx context.Context, name string, opts ...*options.DropIndexesOptions) (bson.Raw, error) {
+	dr, err := iv.dropWithResult(ctx, name, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// TODO: it's weird to return a bson.Raw here because we have to convert the result back to BSON
+	ridx, res := bsoncore.AppendDocumentStart(nil)
+	res = bsoncore.AppendInt32Element(res, "nIndexesWas", dr.NIndexesWas)
+	res, _ = bsoncore.AppendDocumentEnd(res, ridx)
+	return res, nil
+}
+
+func (iv IndexView) dropWithResult(
+	ctx context.Context,
+	name string,
+	opts ...*options.DropIndexesOptions,
+) (*DropIndexesResult, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -403,17 +1849,26 @@ func (iv IndexView) drop(ctx context.Context, name string, opts ...*options.Drop
 		Database(iv.coll.db.name).Collection(iv.coll.name).
 		Deployment(iv.coll.client.deployment).ServerAPI(iv.coll.client.serverAPI).
 		Timeout(iv.coll.client.timeout).MaxTime(dio.MaxTime)
+	if dio.OperationName != nil {
+		op.OperationName(*dio.OperationName)
+	}
+	if dio.Timeout != nil {
+		op.Timeout(dio.Timeout)
+	}
 
 	err = op.Execute(ctx)
 	if err != nil {
-		return nil, replaceErrors(err)
+		err = replaceErrors(err)
+		if isNotPrimaryIndexError(err) {
+			return nil, notPrimaryIndexError(err)
+		}
+		return nil, err
 	}
 
-	// TODO: it's weird to return a bson.Raw here because we have to convert the result back to BSON
-	ridx, res := bsoncore.AppendDocumentStart(nil)
-	res = bsoncore.AppendInt32Element(res, "nIndexesWas", op.Result().NIndexesWas)
-	res, _ = bsoncore.AppendDocumentEnd(res, ridx)
-	return res, nil
+	return &DropIndexesResult{
+		NIndexesWas: op.Result().NIndexesWas,
+		Server:      op.ServerDescription(),
+	}, nil
 }
 
 // DropOne executes a dropIndexes operation to drop an index on the collection. If the operation succeeds, this returns
@@ -435,6 +1890,21 @@ func (iv IndexView) DropOne(ctx context.Context, name string, opts ...*options.D
 	return iv.drop(ctx, name, opts...)
 }
 
+// DropOneWithResult executes a dropIndexes operation to drop an index on the collection in the same way as
+// IndexView.DropOne and returns a DropIndexesResult that includes the description of the server that executed the
+// command.
+func (iv IndexView) DropOneWithResult(
+	ctx context.Context,
+	name string,
+	opts ...*options.DropIndexesOptions,
+) (*DropIndexesResult, error) {
+	if name == "*" {
+		return nil, ErrMultipleIndexDrop
+	}
+
+	return iv.dropWithResult(ctx, name, opts...)
+}
+
 // DropAll executes a dropIndexes operation to drop all indexes on the collection. If the operation succeeds, this
 // returns a BSON document in the form {nIndexesWas: <int32>}. The "nIndexesWas" field in the response contains the
 // number of indexes that existed prior to the drop.
@@ -447,6 +1917,106 @@ func (iv IndexView) DropAll(ctx context.Context, opts ...*options.DropIndexesOpt
 	return iv.drop(ctx, "*", opts...)
 }
 
+// DropMany executes a dropIndexes operation for each of the given index names and returns a DropResult for each name
+// that reports whether the index was dropped or was already absent. An index is considered absent if the server returns
+// an IndexNotFound error or the collection does not exist, so DropMany can be used for idempotent cleanup.
+//
+// If any name is "*", ErrMultipleIndexDrop will be returned without running any commands. If a dropIndexes operation
+// fails for another reason, DropMany stops and returns the results for the names that were processed along with the
+// error.
+//
+// The opts parameter can be used to specify options for each dropIndexes operation (see the
+// options.DropIndexesOptions documentation).
+func (iv IndexView) DropMany(
+	ctx context.Context,
+	names []string,
+	opts ...*options.DropIndexesOptions,
+) (map[string]DropResult, error) {
+	for _, name := range names {
+		if name == "*" {
+			return nil, ErrMultipleIndexDrop
+		}
+	}
+
+	results := make(map[string]DropResult, len(names))
+	for _, name := range names {
+		if _, ok := results[name]; ok {
+			continue
+		}
+
+		_, err := iv.drop(ctx, name, opts...)
+		switch {
+		case err == nil:
+			results[name] = DropResult{Dropped: true}
+		case IsIndexNotFoundError(err) || IsNamespaceNotFoundError(err):
+			results[name] = DropResult{Dropped: false}
+		default:
+			return results, fmt.Errorf("error dropping index %q: %w", name, err)
+		}
+	}
+	return results, nil
+}
+
+// DropMatching lists the indexes on the collection and executes a dropIndexes operation for each index whose name
+// matches the given glob pattern, returning the names of the dropped indexes in the order they were listed. The pattern
+// syntax is that of path.Match, so "tmp_*" matches every index whose name starts with "tmp_". The "_id_" index is
+// never dropped, and "*" is matched against index names rather than being sent to the server as a request to drop all
+// indexes.
+//
+// A pattern made up only of wildcard characters that includes "*", such as "*" or "?*", matches every index name.
+// DropMatching returns ErrMultipleIndexDrop without running any commands for such a pattern unless the AllowMatchAll
+// option is set to true.
+// If a dropIndexes operation fails, DropMatching stops and returns the names dropped so far along with the error.
+// Indexes that were dropped concurrently after being listed are skipped.
+//
+// The opts parameter can be used to specify options for each dropIndexes operation (see the
+// options.DropIndexesOptions documentation).
+func (iv IndexView) DropMatching(
+	ctx context.Context,
+	pattern string,
+	opts ...*options.DropIndexesOptions,
+) ([]string, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid index name pattern %q: %w", pattern, err)
+	}
+	dio := options.MergeDropIndexesOptions(opts...)
+	if isMatchAllPattern(pattern) && (dio.AllowMatchAll == nil || !*dio.AllowMatchAll) {
+		return nil, ErrMultipleIndexDrop
+	}
+
+	specs, err := iv.ListSpecifications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var dropped []string
+	for _, spec := range specs {
+		if spec.Name == "_id_" {
+			continue
+		}
+		// The pattern was validated above, so Match cannot return an error.
+		if ok, _ := path.Match(pattern, spec.Name); !ok {
+			continue
+		}
+
+		_, err := iv.drop(ctx, spec.Name, opts...)
+		switch {
+		case err == nil:
+			dropped = append(dropped, spec.Name)
+		case IsIndexNotFoundError(err) || IsNamespaceNotFoundError(err):
+		default:
+			return dropped, fmt.Errorf("error dropping index %q: %w", spec.Name, err)
+		}
+	}
+	return dropped, nil
+}
+
+// isMatchAllPattern reports whether pattern contains only wildcard characters and therefore matches every non-empty
+// index name.
+func isMatchAllPattern(pattern string) bool {
+	return strings.Trim(pattern, "*?") == "" && strings.Contains(pattern, "*")
+}
+
 func getOrGenerateIndexName(keySpecDocument bsoncore.Document, model IndexModel) (string, error) {
 	if model.Options != nil && model.Options.Name != nil {
 		return *model.Options.Name, nil
@@ -0,0 +1,1447 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/require"
+	"go.mongodb.org/mongo-driver/mongo/address"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
+)
+
+func TestIndexErrorPredicates(t *testing.T) {
+	t.Parallel()
+
+	predicates := []struct {
+		name string
+		code int32
+		fn   func(error) bool
+	}{
+		{"IsNamespaceNotFoundError", 26, IsNamespaceNotFoundError},
+		{"IsIndexNotFoundError", 27, IsIndexNotFoundError},
+		{"IsIndexOptionsConflictError", 85, IsIndexOptionsConflictError},
+		{"IsIndexKeySpecsConflictError", 86, IsIndexKeySpecsConflictError},
+	}
+
+	for _, p := range predicates {
+		p := p // Capture range variable.
+
+		t.Run(p.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCases := []struct {
+				desc string
+				err  error
+				want bool
+			}{
+				{"driver.Error with matching code", driver.Error{Code: p.code}, true},
+				{"driver.Error with different code", driver.Error{Code: 1}, false},
+				{"wrapped driver.Error", fmt.Errorf("wrapped: %w", driver.Error{Code: p.code}), true},
+				{"CommandError with matching code", CommandError{Code: p.code}, true},
+				{"CommandError with different code", CommandError{Code: 1}, false},
+				{"non-server error", errors.New("foo"), false},
+				{"nil", nil, false},
+			}
+			for _, tc := range testCases {
+				got := p.fn(tc.err)
+				assert.Equal(t, tc.want, got, "%s: expected %v, got %v", tc.desc, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestDesiredIndexMatchesSpec(t *testing.T) {
+	t.Parallel()
+
+	iv := IndexView{coll: &Collection{}}
+
+	testCases := []struct {
+		name  string
+		model IndexModel
+		spec  bson.D
+		want  bool
+	}{
+		{
+			name:  "same keys",
+			model: IndexModel{Keys: bson.D{{"a", 1}}},
+			spec:  bson.D{{"v", 2}, {"key", bson.D{{"a", int32(1)}}}, {"name", "a_1"}},
+			want:  true,
+		},
+		{
+			name:  "different key direction",
+			model: IndexModel{Keys: bson.D{{"a", 1}}},
+			spec:  bson.D{{"v", 2}, {"key", bson.D{{"a", int32(-1)}}}, {"name", "a_1"}},
+			want:  false,
+		},
+		{
+			name:  "numeric types are compared by value",
+			model: IndexModel{Keys: bson.D{{"a", 1}}, Options: options.Index().SetExpireAfterSeconds(60)},
+			spec:  bson.D{{"key", bson.D{{"a", 1.0}}}, {"name", "a_1"}, {"expireAfterSeconds", int64(60)}},
+			want:  true,
+		},
+		{
+			name:  "different expireAfterSeconds",
+			model: IndexModel{Keys: bson.D{{"a", 1}}, Options: options.Index().SetExpireAfterSeconds(60)},
+			spec:  bson.D{{"key", bson.D{{"a", 1}}}, {"name", "a_1"}, {"expireAfterSeconds", 120}},
+			want:  false,
+		},
+		{
+			name:  "unique on server only",
+			model: IndexModel{Keys: bson.D{{"a", 1}}},
+			spec:  bson.D{{"key", bson.D{{"a", 1}}}, {"name", "a_1"}, {"unique", true}},
+			want:  false,
+		},
+		{
+			name:  "explicit false matches missing",
+			model: IndexModel{Keys: bson.D{{"a", 1}}, Options: options.Index().SetHidden(false)},
+			spec:  bson.D{{"key", bson.D{{"a", 1}}}, {"name", "a_1"}},
+			want:  true,
+		},
+		{
+			name: "collation subset",
+			model: IndexModel{
+				Keys:    bson.D{{"a", 1}},
+				Options: options.Index().SetCollation(&options.Collation{Locale: "en", Strength: 2}),
+			},
+			spec: bson.D{
+				{"key", bson.D{{"a", 1}}},
+				{"name", "a_1"},
+				{"collation", bson.D{{"locale", "en"}, {"caseLevel", false}, {"strength", 2}}},
+			},
+			want: true,
+		},
+		{
+			name:  "text index",
+			model: IndexModel{Keys: bson.D{{"title", "text"}, {"body", "text"}}},
+			spec: bson.D{
+				{"key", bson.D{{"_fts", "text"}, {"_ftsx", 1}}},
+				{"name", "title_text_body_text"},
+				{"weights", bson.D{{"body", 1}, {"title", 1}}},
+				{"default_language", "english"},
+			},
+			want: true,
+		},
+		{
+			name:  "text index with different fields",
+			model: IndexModel{Keys: bson.D{{"title", "text"}, {"body", "text"}}},
+			spec: bson.D{
+				{"key", bson.D{{"_fts", "text"}, {"_ftsx", 1}}},
+				{"name", "title_text_body_text"},
+				{"weights", bson.D{{"title", 1}}},
+			},
+			want: false,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // Capture range variable.
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			desired, err := iv.desiredIndexes([]IndexModel{tc.model})
+			require.NoError(t, err, "desiredIndexes error")
+
+			spec, err := bson.Marshal(tc.spec)
+			require.NoError(t, err, "Marshal error")
+
+			got := desired[0].matchesSpec(spec)
+			assert.Equal(t, tc.want, got, "expected matchesSpec to return %v, got %v", tc.want, got)
+		})
+	}
+}
+
+func TestGeoIndexOptions(t *testing.T) {
+	t.Parallel()
+
+	iv := IndexView{coll: &Collection{}}
+
+	testCases := []struct {
+		name string
+		opts *options.IndexOptions
+		want bsoncore.Document
+	}{
+		{
+			name: "2dsphere",
+			opts: options.GeoIndex2DSphere(),
+			want: bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendInt32Element(nil, "2dsphereIndexVersion", 3),
+			),
+		},
+		{
+			name: "2d defaults",
+			opts: options.GeoIndex2D(),
+			want: bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendInt32Element(nil, "bits", 26),
+				bsoncore.AppendDoubleElement(nil, "max", 180),
+				bsoncore.AppendDoubleElement(nil, "min", -180),
+			),
+		},
+		{
+			name: "2d custom bounds",
+			opts: options.GeoIndex2D().SetBits(32).SetMin(0).SetMax(1000),
+			want: bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendInt32Element(nil, "bits", 32),
+				bsoncore.AppendDoubleElement(nil, "max", 1000),
+				bsoncore.AppendDoubleElement(nil, "min", 0),
+			),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // Capture range variable.
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			optsDoc, err := iv.createOptionsDoc(tc.opts)
+			require.NoError(t, err, "createOptionsDoc error")
+
+			got := bsoncore.Document(bsoncore.BuildDocument(nil, optsDoc))
+			assert.Equal(t, tc.want, got, "expected options document %v, got %v", tc.want, got)
+		})
+	}
+}
+
+func TestShardSelector(t *testing.T) {
+	t.Parallel()
+
+	shard01a := description.Server{Addr: address.Address("shard01a:27017"), Kind: description.RSPrimary, SetName: "shard01"}
+	shard01b := description.Server{Addr: address.Address("shard01b:27017"), Kind: description.RSSecondary, SetName: "shard01"}
+	shard02a := description.Server{Addr: address.Address("shard02a:27017"), Kind: description.RSPrimary, SetName: "shard02"}
+	candidates := []description.Server{shard01a, shard01b, shard02a}
+	topo := description.Topology{Kind: description.ReplicaSetWithPrimary, Servers: candidates}
+
+	t.Run("selects members of the shard", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := shardSelector("shard02").SelectServer(topo, candidates)
+		require.NoError(t, err, "SelectServer error")
+		assert.Equal(t, []description.Server{shard02a}, got, "expected only shard02 members to be selected")
+	})
+	t.Run("combined with write selector", func(t *testing.T) {
+		t.Parallel()
+
+		selector := description.CompositeSelector([]description.ServerSelector{
+			shardSelector("shard01"),
+			description.WriteSelector(),
+		})
+		got, err := selector.SelectServer(topo, candidates)
+		require.NoError(t, err, "SelectServer error")
+		assert.Equal(t, []description.Server{shard01a}, got, "expected the shard01 primary to be selected")
+	})
+	t.Run("unknown shard", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := shardSelector("shard03").SelectServer(topo, candidates)
+		require.NoError(t, err, "SelectServer error")
+		assert.Equal(t, 0, len(got), "expected no servers to be selected, got %v", got)
+	})
+	t.Run("connected through mongos", func(t *testing.T) {
+		t.Parallel()
+
+		mongos := description.Server{Addr: address.Address("mongos:27017"), Kind: description.Mongos}
+		shardedTopo := description.Topology{Kind: description.Sharded, Servers: []description.Server{mongos}}
+
+		_, err := shardSelector("shard01").SelectServer(shardedTopo, []description.Server{mongos})
+		assert.Equal(t, ErrShardRequiresDirectConnection, err, "expected error %v, got %v",
+			ErrShardRequiresDirectConnection, err)
+	})
+	t.Run("standalone", func(t *testing.T) {
+		t.Parallel()
+
+		standalone := description.Server{Addr: address.Address("localhost:27017"), Kind: description.Standalone}
+		singleTopo := description.Topology{Kind: description.Single, Servers: []description.Server{standalone}}
+
+		_, err := shardSelector("shard01").SelectServer(singleTopo, []description.Server{standalone})
+		assert.Equal(t, ErrShardRequiresDirectConnection, err, "expected error %v, got %v",
+			ErrShardRequiresDirectConnection, err)
+	})
+	t.Run("no candidates yet", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := shardSelector("shard01").SelectServer(description.Topology{Kind: description.Unknown}, nil)
+		require.NoError(t, err, "SelectServer error")
+		assert.Equal(t, 0, len(got), "expected no servers to be selected, got %v", got)
+	})
+}
+
+func TestMarshalAuditComment(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		comment interface{}
+		wantErr bool
+	}{
+		{"document", bson.D{{"tool", "migrator"}, {"version", 3}}, false},
+		{"single key map", bson.M{"tool": "migrator"}, false},
+		{"multi-key map", bson.M{"tool": "migrator", "version": 3}, true},
+		{"empty document", bson.D{}, true},
+		{"operator field", bson.D{{"$tool", "migrator"}}, true},
+		{"string", "migrator", true},
+	}
+	for _, tc := range testCases {
+		tc := tc // Capture range variable.
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := marshalAuditComment(tc.comment, nil, nil)
+			if tc.wantErr {
+				assert.NotNil(t, err, "expected marshalAuditComment error, got nil")
+				return
+			}
+			assert.Nil(t, err, "marshalAuditComment error: %v", err)
+		})
+	}
+}
+
+func TestIndexModelFromSpec(t *testing.T) {
+	t.Parallel()
+
+	iv := IndexView{coll: &Collection{}}
+
+	testCases := []struct {
+		name     string
+		spec     bson.D
+		wantKeys bson.D
+		wantOpts *options.IndexOptions
+		wantErr  bool
+		// The option reported by the expected IndexOptionTypeError, if any.
+		wantTypeErr string
+	}{
+		{
+			name:     "plain",
+			spec:     bson.D{{"v", 2}, {"key", bson.D{{"a", 1}}}, {"name", "a_1"}},
+			wantKeys: bson.D{{"a", 1}},
+			wantOpts: options.Index().SetName("a_1"),
+		},
+		{
+			name:     "ttl",
+			spec:     bson.D{{"v", 2}, {"key", bson.D{{"createdAt", 1}}}, {"name", "ttl"}, {"expireAfterSeconds", 3600}},
+			wantKeys: bson.D{{"createdAt", 1}},
+			wantOpts: options.Index().SetName("ttl").SetExpireAfterSeconds(3600),
+		},
+		{
+			name: "partial unique",
+			spec: bson.D{
+				{"v", 2},
+				{"key", bson.D{{"email", 1}}},
+				{"name", "email_1"},
+				{"unique", true},
+				{"partialFilterExpression", bson.D{{"active", true}}},
+			},
+			wantKeys: bson.D{{"email", 1}},
+			wantOpts: options.Index().SetName("email_1").SetUnique(true).
+				SetPartialFilterExpression(bson.D{{"active", true}}),
+		},
+		{
+			name: "text",
+			spec: bson.D{
+				{"v", 2},
+				{"key", bson.D{{"category", 1}, {"_fts", "text"}, {"_ftsx", 1}}},
+				{"name", "text"},
+				{"weights", bson.D{{"body", 1}, {"title", 10}}},
+				{"default_language", "english"},
+				{"language_override", "language"},
+				{"textIndexVersion", 3},
+			},
+			wantKeys: bson.D{{"category", 1}, {"body", "text"}, {"title", "text"}},
+			wantOpts: options.Index().SetName("text").SetWeights(bson.D{{"body", 1}, {"title", 10}}).
+				SetDefaultLanguage("english").SetLanguageOverride("language").SetTextVersion(3),
+		},
+		{
+			name: "numeric booleans",
+			spec: bson.D{
+				{"v", 1},
+				{"key", bson.D{{"a", 1}}},
+				{"name", "a_1"},
+				{"unique", int32(1)},
+				{"sparse", int64(1)},
+				{"hidden", 0.0},
+			},
+			wantKeys: bson.D{{"a", 1}},
+			wantOpts: options.Index().SetName("a_1").SetUnique(true).SetSparse(true).SetHidden(false),
+		},
+		{
+			name:        "non-string name",
+			spec:        bson.D{{"v", 2}, {"key", bson.D{{"a", 1}}}, {"name", 5}},
+			wantErr:     true,
+			wantTypeErr: "name",
+		},
+		{
+			name:        "string unique",
+			spec:        bson.D{{"v", 2}, {"key", bson.D{{"a", 1}}}, {"name", "a_1"}, {"unique", "yes"}},
+			wantErr:     true,
+			wantTypeErr: "unique",
+		},
+		{
+			name:        "non-string default language",
+			spec:        bson.D{{"v", 2}, {"key", bson.D{{"a", "text"}}}, {"name", "a_text"}, {"default_language", 1}},
+			wantErr:     true,
+			wantTypeErr: "default_language",
+		},
+		{
+			name:        "non-document partial filter",
+			spec:        bson.D{{"v", 2}, {"key", bson.D{{"a", 1}}}, {"name", "a_1"}, {"partialFilterExpression", 1}},
+			wantErr:     true,
+			wantTypeErr: "partialFilterExpression",
+		},
+		{
+			name:        "non-document weights",
+			spec:        bson.D{{"v", 2}, {"key", bson.D{{"a", 1}}}, {"name", "a_1"}, {"weights", "a"}},
+			wantErr:     true,
+			wantTypeErr: "weights",
+		},
+		{
+			name:        "non-document key",
+			spec:        bson.D{{"v", 2}, {"key", "a"}, {"name", "a_1"}},
+			wantErr:     true,
+			wantTypeErr: "key",
+		},
+		{
+			name:    "unsupported option",
+			spec:    bson.D{{"v", 2}, {"key", bson.D{{"_id", 1}}}, {"name", "_id_"}, {"clustered", true}},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // Capture range variable.
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			spec, err := bson.Marshal(tc.spec)
+			require.NoError(t, err, "Marshal error")
+
+			model, err := indexModelFromSpec(spec)
+			if tc.wantErr {
+				assert.NotNil(t, err, "expected indexModelFromSpec error, got nil")
+				if tc.wantTypeErr != "" {
+					var typeErr IndexOptionTypeError
+					require.True(t, errors.As(err, &typeErr), "expected IndexOptionTypeError, got %v", err)
+					assert.Equal(t, tc.wantTypeErr, typeErr.Option, "expected option %q, got %q", tc.wantTypeErr,
+						typeErr.Option)
+				}
+				return
+			}
+			require.NoError(t, err, "indexModelFromSpec error")
+
+			wantKeys, err := bson.Marshal(tc.wantKeys)
+			require.NoError(t, err, "Marshal error")
+			assert.Equal(t, bson.Raw(wantKeys), model.Keys, "expected keys %v, got %v", bson.Raw(wantKeys), model.Keys)
+
+			wantOpts, err := iv.createOptionsDoc(tc.wantOpts)
+			require.NoError(t, err, "createOptionsDoc error")
+			gotOpts, err := iv.createOptionsDoc(model.Options)
+			require.NoError(t, err, "createOptionsDoc error")
+			assert.Equal(t, wantOpts, gotOpts, "expected options %v, got %v", wantOpts, gotOpts)
+		})
+	}
+}
+
+func TestIndexModelFromLegacy(t *testing.T) {
+	t.Parallel()
+
+	iv := IndexView{coll: &Collection{}}
+
+	testCases := []struct {
+		name        string
+		spec        bson.D
+		wantKeys    bson.D
+		wantOpts    *options.IndexOptions
+		wantUnknown []string
+		wantErr     bool
+	}{
+		{
+			name:     "keys only",
+			spec:     bson.D{{"key", bson.D{{"a", 1}, {"b", -1}}}},
+			wantKeys: bson.D{{"a", 1}, {"b", -1}},
+			wantOpts: options.Index(),
+		},
+		{
+			name: "ensureIndex options",
+			spec: bson.D{
+				{"v", 1},
+				{"key", bson.D{{"email", 1}}},
+				{"name", "email_1"},
+				{"ns", "db.users"},
+				{"background", true},
+				{"unique", true},
+				{"sparse", true},
+			},
+			wantKeys: bson.D{{"email", 1}},
+			wantOpts: options.Index().SetName("email_1").SetUnique(true).SetSparse(true),
+		},
+		{
+			name: "ttl with double expiry",
+			spec: bson.D{
+				{"key", bson.D{{"createdAt", 1}}},
+				{"expireAfterSeconds", 86400.0},
+				{"partialFilterExpression", bson.D{{"archived", false}}},
+			},
+			wantKeys: bson.D{{"createdAt", 1}},
+			wantOpts: options.Index().SetExpireAfterSeconds(86400).
+				SetPartialFilterExpression(bson.D{{"archived", false}}),
+		},
+		{
+			name:        "unknown fields",
+			spec:        bson.D{{"key", bson.D{{"sku", 1}}}, {"unique", true}, {"dropDups", true}, {"safe", true}},
+			wantKeys:    bson.D{{"sku", 1}},
+			wantOpts:    options.Index().SetUnique(true),
+			wantUnknown: []string{"dropDups", "safe"},
+		},
+		{
+			name: "numeric booleans",
+			spec: bson.D{
+				{"key", bson.D{{"sku", 1}}},
+				{"unique", 1},
+				{"sparse", int64(0)},
+				{"dropDups", 1},
+			},
+			wantKeys:    bson.D{{"sku", 1}},
+			wantOpts:    options.Index().SetUnique(true).SetSparse(false),
+			wantUnknown: []string{"dropDups"},
+		},
+		{
+			name:    "string unique",
+			spec:    bson.D{{"key", bson.D{{"sku", 1}}}, {"unique", "true"}},
+			wantErr: true,
+		},
+		{
+			name:    "non-string name",
+			spec:    bson.D{{"key", bson.D{{"sku", 1}}}, {"name", 1}},
+			wantErr: true,
+		},
+		{
+			name:    "missing key",
+			spec:    bson.D{{"name", "a_1"}},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // Capture range variable.
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			model, err := IndexModelFromLegacy(tc.spec)
+			if tc.wantErr {
+				assert.NotNil(t, err, "expected IndexModelFromLegacy error, got nil")
+				var unknownErr UnknownIndexOptionsError
+				assert.False(t, errors.As(err, &unknownErr), "expected a parse error, got %v", err)
+				return
+			}
+			if tc.wantUnknown != nil {
+				var unknownErr UnknownIndexOptionsError
+				require.True(t, errors.As(err, &unknownErr), "expected UnknownIndexOptionsError, got %v", err)
+				assert.Equal(t, tc.wantUnknown, unknownErr.Fields, "expected unknown fields %v, got %v",
+					tc.wantUnknown, unknownErr.Fields)
+			} else {
+				require.NoError(t, err, "IndexModelFromLegacy error")
+			}
+
+			wantKeys, err := bson.Marshal(tc.wantKeys)
+			require.NoError(t, err, "Marshal error")
+			assert.Equal(t, bson.Raw(wantKeys), model.Keys, "expected keys %v, got %v", bson.Raw(wantKeys), model.Keys)
+
+			wantOpts, err := iv.createOptionsDoc(tc.wantOpts)
+			require.NoError(t, err, "createOptionsDoc error")
+			gotOpts, err := iv.createOptionsDoc(model.Options)
+			require.NoError(t, err, "createOptionsDoc error")
+			assert.Equal(t, wantOpts, gotOpts, "expected options %v, got %v", wantOpts, gotOpts)
+		})
+	}
+}
+
+func TestCreateOptionsDocCollation(t *testing.T) {
+	t.Parallel()
+
+	iv := IndexView{coll: &Collection{}}
+
+	t.Run("with locale", func(t *testing.T) {
+		t.Parallel()
+
+		doc, err := iv.createOptionsDoc(options.Index().SetCollation(options.NewCollation("en").SetStrength(2)))
+		require.NoError(t, err, "createOptionsDoc error")
+		locale, err := bsoncore.Document(bsoncore.BuildDocument(nil, doc)).LookupErr("collation", "locale")
+		require.NoError(t, err, "expected collation.locale in options %v", doc)
+		assert.Equal(t, "en", locale.StringValue(), "expected locale %q, got %v", "en", locale)
+	})
+	t.Run("without locale", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := iv.createOptionsDoc(options.Index().SetCollation(&options.Collation{Strength: 2}))
+		assert.NotNil(t, err, "expected createOptionsDoc error, got nil")
+	})
+}
+
+func TestCreateOptionsDocVersion(t *testing.T) {
+	t.Parallel()
+
+	iv := IndexView{coll: &Collection{}}
+
+	for _, version := range []int32{1, 2} {
+		doc, err := iv.createOptionsDoc(options.Index().SetVersion(version))
+		require.NoError(t, err, "createOptionsDoc error for version %d", version)
+		got, err := bsoncore.Document(bsoncore.BuildDocument(nil, doc)).LookupErr("v")
+		require.NoError(t, err, "expected v in options %v", doc)
+		assert.Equal(t, version, got.Int32(), "expected version %d, got %v", version, got)
+	}
+	for _, version := range []int32{-1, 0, 3} {
+		_, err := iv.createOptionsDoc(options.Index().SetVersion(version))
+		assert.NotNil(t, err, "expected createOptionsDoc error for version %d, got nil", version)
+	}
+}
+
+func TestIndexBuildOrder(t *testing.T) {
+	t.Parallel()
+
+	keys := func(d bson.D) bsoncore.Document {
+		doc, err := bson.Marshal(d)
+		require.NoError(t, err, "Marshal error")
+		return doc
+	}
+	keysDocs := []bsoncore.Document{
+		keys(bson.D{{"body", "text"}}),
+		keys(bson.D{{"a", 1}, {"b", 1}}),
+		keys(bson.D{{"loc", "2dsphere"}}),
+		keys(bson.D{{"c", 1}}),
+		keys(bson.D{{"d", 1}}),
+	}
+	optsDocs := []bsoncore.Document{
+		nil,
+		nil,
+		nil,
+		bsoncore.AppendBooleanElement(nil, "unique", true),
+		nil,
+	}
+
+	testCases := []struct {
+		name  string
+		order options.IndexBuildOrder
+		want  []int
+	}{
+		{"as given", options.IndexBuildOrderAsGiven, []int{0, 1, 2, 3, 4}},
+		{"by cost", options.IndexBuildOrderByCost, []int{4, 1, 3, 2, 0}},
+	}
+	for _, tc := range testCases {
+		tc := tc // Capture range variable.
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := indexBuildOrder(tc.order, keysDocs, optsDocs)
+			assert.Equal(t, tc.want, got, "expected order %v, got %v", tc.want, got)
+		})
+	}
+}
+
+func TestCreateOptionsDocWildcardProjection(t *testing.T) {
+	t.Parallel()
+
+	iv := IndexView{coll: &Collection{}}
+
+	doc, err := iv.createOptionsDoc(options.Index().SetWildcardProjection(options.WildcardProjection().Include("a.b")))
+	require.NoError(t, err, "createOptionsDoc error")
+	val, err := bsoncore.Document(bsoncore.BuildDocument(nil, doc)).LookupErr("wildcardProjection", "a.b")
+	require.NoError(t, err, "expected wildcardProjection.a.b in options %v", doc)
+	assert.Equal(t, int32(1), val.Int32(), "expected projection value 1, got %v", val)
+
+	mixed := options.WildcardProjection().Include("a").Exclude("b")
+	_, err = iv.createOptionsDoc(options.Index().SetWildcardProjection(mixed))
+	assert.NotNil(t, err, "expected createOptionsDoc error, got nil")
+}
+
+func TestValidateAgainstSample(t *testing.T) {
+	t.Parallel()
+
+	sample := bson.D{
+		{"name", "Central Park"},
+		{"tags", bson.A{"park", "nyc"}},
+		{"visits", 1200},
+		{"location", bson.D{{"type", "Point"}, {"coordinates", bson.A{-73.97, 40.77}}}},
+		{"legacy", bson.A{-73.97, 40.77}},
+		{"address", bson.D{{"city", "New York"}, {"zip", "10024"}}},
+		{"reviews", bson.A{bson.D{{"stars", 5}}, bson.D{{"stars", 4}}}},
+	}
+
+	testCases := []struct {
+		name             string
+		keys             bson.D
+		wantMissing      []string
+		wantIncompatible []string
+	}{
+		{"single field", bson.D{{"visits", -1}}, nil, nil},
+		{"compound with dotted path", bson.D{{"address.city", 1}, {"visits", 1}}, nil, nil},
+		{"path through array of documents", bson.D{{"reviews.stars", 1}}, nil, nil},
+		{"text", bson.D{{"name", "text"}, {"tags", "text"}}, nil, nil},
+		{"2dsphere GeoJSON", bson.D{{"location", "2dsphere"}}, nil, nil},
+		{"2dsphere coordinate pair", bson.D{{"legacy", "2dsphere"}}, nil, nil},
+		{"2d", bson.D{{"legacy", "2d"}}, nil, nil},
+		{"hashed", bson.D{{"name", "hashed"}}, nil, nil},
+		{"wildcard", bson.D{{"$**", 1}}, nil, nil},
+		{"wildcard with prefix", bson.D{{"address.$**", 1}}, nil, nil},
+		{"typo", bson.D{{"vists", 1}}, []string{"vists"}, nil},
+		{"missing nested field", bson.D{{"address.state", 1}, {"reviews.author", 1}}, []string{"address.state", "reviews.author"}, nil},
+		{"missing wildcard prefix", bson.D{{"attributes.$**", 1}}, []string{"attributes.$**"}, nil},
+		{"2dsphere on string", bson.D{{"name", "2dsphere"}}, nil, []string{"name"}},
+		{"2d on GeoJSON", bson.D{{"location", "2d"}}, nil, []string{"location"}},
+		{"text on number", bson.D{{"visits", "text"}}, nil, []string{"visits"}},
+		{"hashed on array", bson.D{{"tags", "hashed"}}, nil, []string{"tags"}},
+		{"missing and incompatible", bson.D{{"nmae", 1}, {"address", "2dsphere"}}, []string{"nmae"}, []string{"address"}},
+	}
+	for _, tc := range testCases {
+		tc := tc // Capture range variable.
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateAgainstSample(IndexModel{Keys: tc.keys}, sample)
+			if tc.wantMissing == nil && tc.wantIncompatible == nil {
+				assert.Nil(t, err, "ValidateAgainstSample error: %v", err)
+				return
+			}
+
+			var mismatch IndexSampleMismatchError
+			require.True(t, errors.As(err, &mismatch), "expected IndexSampleMismatchError, got %v", err)
+			assert.Equal(t, tc.wantMissing, mismatch.Missing, "expected missing fields %v, got %v", tc.wantMissing,
+				mismatch.Missing)
+
+			incompatible := make([]string, 0, len(mismatch.Incompatible))
+			for field := range mismatch.Incompatible {
+				incompatible = append(incompatible, field)
+			}
+			if tc.wantIncompatible == nil {
+				assert.Equal(t, 0, len(incompatible), "expected no incompatible fields, got %v", incompatible)
+				return
+			}
+			assert.Equal(t, tc.wantIncompatible, incompatible, "expected incompatible fields %v, got %v",
+				tc.wantIncompatible, incompatible)
+		})
+	}
+	t.Run("empty keys", func(t *testing.T) {
+		t.Parallel()
+
+		err := ValidateAgainstSample(IndexModel{Keys: bson.D{}}, sample)
+		assert.NotNil(t, err, "expected ValidateAgainstSample error, got nil")
+	})
+}
+
+func TestValidateCommitQuorum(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		quorum  interface{}
+		wantErr bool
+	}{
+		{"majority", "majority", false},
+		{"votingMembers", "votingMembers", false},
+		{"int32", int32(2), false},
+		{"int", 0, false},
+		{"tag", options.CommitQuorumTag("dataCenters"), false},
+		{"tag set document", bson.D{{"dc", 2}}, false},
+		{"plain string tag", "dataCenters", false},
+		{"tag similar to alias", "majorityDC", false},
+		{"wrong case majority", "Majority", true},
+		{"wrong case votingMembers", "votingmembers", true},
+		{"tag with alias name", options.CommitQuorumTag("Majority"), false},
+	}
+	for _, tc := range testCases {
+		tc := tc // Capture range variable.
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateCommitQuorum(tc.quorum)
+			if tc.wantErr {
+				assert.NotNil(t, err, "expected validateCommitQuorum error, got nil")
+				return
+			}
+			assert.Nil(t, err, "validateCommitQuorum error: %v", err)
+		})
+	}
+}
+
+func TestIsMatchAllPattern(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		pattern string
+		want    bool
+	}{
+		{"*", true},
+		{"**", true},
+		{"?*", true},
+		{"?", false},
+		{"tmp_*", false},
+		{"*_1", false},
+		{"", false},
+	}
+	for _, tc := range testCases {
+		got := isMatchAllPattern(tc.pattern)
+		assert.Equal(t, tc.want, got, "expected isMatchAllPattern(%q) to be %v, got %v", tc.pattern, tc.want, got)
+	}
+}
+
+func TestServerIndexNames(t *testing.T) {
+	t.Parallel()
+
+	rawSpec := func(name string, keys bson.D) bson.Raw {
+		doc, err := bson.Marshal(bson.D{{"v", 2}, {"key", keys}, {"name", name}})
+		require.NoError(t, err, "Marshal error")
+		return doc
+	}
+	keysDoc := func(keys bson.D) bsoncore.Document {
+		doc, err := bson.Marshal(keys)
+		require.NoError(t, err, "Marshal error")
+		return doc
+	}
+
+	specs := []bson.Raw{
+		rawSpec("_id_", bson.D{{"_id", 1}}),
+		rawSpec("a_1", bson.D{{"a", 1}}),
+		rawSpec("title_body_text", bson.D{{"_fts", "text"}, {"_ftsx", 1}}),
+		rawSpec("b_1_en", bson.D{{"b", 1}}),
+		rawSpec("b_1_fr", bson.D{{"b", 1}}),
+	}
+
+	testCases := []struct {
+		name  string
+		names []string
+		keys  []bson.D
+		want  []string
+	}{
+		{"name exists", []string{"a_1"}, []bson.D{{{"a", 1}}}, []string{"a_1"}},
+		{
+			"text index renamed by server",
+			[]string{"title_text_body_text"},
+			[]bson.D{{{"title", "text"}, {"body", "text"}}},
+			[]string{"title_body_text"},
+		},
+		{"ambiguous keys", []string{"b_1"}, []bson.D{{{"b", 1}}}, []string{"b_1"}},
+		{"no matching keys", []string{"c_1"}, []bson.D{{{"c", 1}}}, []string{"c_1"}},
+		{
+			"claimed by name",
+			[]string{"x", "a_1"},
+			[]bson.D{{{"a", 1}}, {{"a", 1}}},
+			[]string{"x", "a_1"},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // Capture range variable.
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			keysDocs := make([]bsoncore.Document, 0, len(tc.keys))
+			for _, keys := range tc.keys {
+				keysDocs = append(keysDocs, keysDoc(keys))
+			}
+			got := serverIndexNames(tc.names, keysDocs, specs)
+			assert.Equal(t, tc.want, got, "expected names %v, got %v", tc.want, got)
+		})
+	}
+}
+
+func TestValidateIndexKeyFields(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		keys    bson.D
+		wantErr bool
+	}{
+		{"single field", bson.D{{"a", 1}}, false},
+		{"dotted path", bson.D{{"a.b", 1}}, false},
+		{"wildcard", bson.D{{"$**", 1}}, false},
+		{"wildcard with prefix", bson.D{{"a.$**", 1}}, false},
+		{"text", bson.D{{"title", "text"}}, false},
+		{"empty keys", bson.D{}, true},
+		{"$natural", bson.D{{"$natural", 1}}, true},
+		{"$natural in compound", bson.D{{"a", 1}, {"$natural", -1}}, true},
+		{"dollar prefix", bson.D{{"$a", 1}}, true},
+		{"dollar prefix in path", bson.D{{"a.$b", 1}}, true},
+		{"wildcard not last", bson.D{{"$**.a", 1}}, true},
+		{"empty field name", bson.D{{"", 1}}, true},
+		{"empty path component", bson.D{{"a..b", 1}}, true},
+	}
+	for _, tc := range testCases {
+		tc := tc // Capture range variable.
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			keys, err := bson.Marshal(tc.keys)
+			require.NoError(t, err, "Marshal error")
+
+			err = validateIndexKeyFields(keys)
+			if tc.wantErr {
+				assert.NotNil(t, err, "expected validateIndexKeyFields error, got nil")
+				return
+			}
+			assert.Nil(t, err, "validateIndexKeyFields error: %v", err)
+		})
+	}
+}
+
+func TestValidateIndexKeyValues(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		keys    bson.D
+		wantErr bool
+	}{
+		{"ascending int32", bson.D{{"a", int32(1)}}, false},
+		{"descending int32", bson.D{{"a", int32(-1)}}, false},
+		{"ascending int64", bson.D{{"a", int64(1)}}, false},
+		{"descending double", bson.D{{"a", -1.0}}, false},
+		{"text", bson.D{{"a", "text"}}, false},
+		{"hashed", bson.D{{"a", "hashed"}}, false},
+		{"2d", bson.D{{"a", "2d"}}, false},
+		{"2dsphere", bson.D{{"a", "2dsphere"}}, false},
+		{"geoHaystack", bson.D{{"a", "geoHaystack"}, {"b", 1}}, false},
+		{"compound", bson.D{{"a", 1}, {"b", -1}, {"c", "text"}}, false},
+		{"zero", bson.D{{"a", 0}}, true},
+		{"two", bson.D{{"a", 2}}, true},
+		{"fractional", bson.D{{"a", 0.5}}, true},
+		{"misspelled type", bson.D{{"a", "2dsphre"}}, true},
+		{"wrong case", bson.D{{"a", "Text"}}, true},
+		{"empty string", bson.D{{"a", ""}}, true},
+		{"boolean", bson.D{{"a", true}}, true},
+		{"invalid value after valid", bson.D{{"a", 1}, {"b", "asc"}}, true},
+	}
+	for _, tc := range testCases {
+		tc := tc // Capture range variable.
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			keys, err := bson.Marshal(tc.keys)
+			require.NoError(t, err, "Marshal error")
+
+			err = validateIndexKeyValues(keys)
+			if tc.wantErr {
+				assert.NotNil(t, err, "expected validateIndexKeyValues error, got nil")
+				return
+			}
+			assert.Nil(t, err, "validateIndexKeyValues error: %v", err)
+		})
+	}
+}
+
+func TestIndexModelConstructors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		model    IndexModel
+		wantKeys bson.D
+		wantOpts *options.IndexOptions
+	}{
+		{"ascending", AscendingIndex("a"), bson.D{{"a", 1}}, nil},
+		{"descending", DescendingIndex("a"), bson.D{{"a", -1}}, nil},
+		{
+			"compound",
+			CompoundIndex(bson.D{{"a", 1}, {"b", -1}, {"loc", "2dsphere"}}),
+			bson.D{{"a", 1}, {"b", -1}, {"loc", "2dsphere"}},
+			nil,
+		},
+		{"text", TextIndex("title", "body"), bson.D{{"title", "text"}, {"body", "text"}}, nil},
+		{
+			"ttl",
+			TTLIndex("createdAt", time.Hour+500*time.Millisecond),
+			bson.D{{"createdAt", 1}},
+			options.Index().SetExpireAfterSeconds(3600),
+		},
+		{
+			"ttl maximum",
+			TTLIndex("createdAt", math.MaxInt32*time.Second),
+			bson.D{{"createdAt", 1}},
+			options.Index().SetExpireAfterSeconds(math.MaxInt32),
+		},
+		{"unique", UniqueIndex("email"), bson.D{{"email", 1}}, options.Index().SetUnique(true)},
+	}
+	for _, tc := range testCases {
+		tc := tc // Capture range variable.
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.wantKeys, tc.model.Keys, "expected keys %v, got %v", tc.wantKeys, tc.model.Keys)
+			assert.Equal(t, tc.wantOpts, tc.model.Options, "expected options %+v, got %+v", tc.wantOpts,
+				tc.model.Options)
+
+			keys, err := marshal(tc.model.Keys, nil, nil)
+			require.NoError(t, err, "marshal error")
+			assert.Nil(t, validateIndexKeyFields(keys), "expected valid index keys")
+		})
+	}
+}
+
+func TestTTLIndexOutOfRange(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		ttl  time.Duration
+	}{
+		{"negative", -time.Second},
+		{"overflow", (math.MaxInt32 + 1) * time.Second},
+	}
+	for _, tc := range testCases {
+		tc := tc // Capture range variable.
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			defer func() {
+				assert.NotNil(t, recover(), "expected TTLIndex to panic for %v", tc.ttl)
+			}()
+			_ = TTLIndex("createdAt", tc.ttl)
+		})
+	}
+}
+
+func TestRetryBackgroundOperationInProgress(t *testing.T) {
+	t.Parallel()
+
+	inProgress := driver.Error{Code: 12587, Name: "BackgroundOperationInProgressForNamespace"}
+
+	t.Run("transient failure then success", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int
+		err := retryBackgroundOperationInProgress(context.Background(), nil, func() error {
+			calls++
+			if calls == 1 {
+				return inProgress
+			}
+			return nil
+		})
+		assert.Nil(t, err, "retryBackgroundOperationInProgress error: %v", err)
+		assert.Equal(t, 2, calls, "expected 2 calls, got %d", calls)
+	})
+	t.Run("permanent failure", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int
+		permanent := driver.Error{Code: 67, Name: "CannotCreateIndex"}
+		err := retryBackgroundOperationInProgress(context.Background(), nil, func() error {
+			calls++
+			return permanent
+		})
+		assert.Equal(t, permanent, err, "expected error %v, got %v", permanent, err)
+		assert.Equal(t, 1, calls, "expected 1 call, got %d", calls)
+	})
+	t.Run("in a transaction", func(t *testing.T) {
+		t.Parallel()
+
+		sess := &session.Client{TransactionState: session.InProgress}
+		var calls int
+		err := retryBackgroundOperationInProgress(context.Background(), sess, func() error {
+			calls++
+			return inProgress
+		})
+		assert.Equal(t, inProgress, err, "expected error %v, got %v", inProgress, err)
+		assert.Equal(t, 1, calls, "expected 1 call, got %d", calls)
+	})
+	t.Run("deadline too close", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), backgroundOperationInitialBackoff/2)
+		defer cancel()
+
+		var calls int
+		err := retryBackgroundOperationInProgress(ctx, nil, func() error {
+			calls++
+			return inProgress
+		})
+		assert.True(t, IsBackgroundOperationInProgressError(err), "expected background operation error, got %v", err)
+		assert.Equal(t, 1, calls, "expected 1 call, got %d", calls)
+	})
+	t.Run("cancelled context", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		var calls int
+		err := retryBackgroundOperationInProgress(ctx, nil, func() error {
+			calls++
+			cancel()
+			return inProgress
+		})
+		assert.True(t, IsBackgroundOperationInProgressError(err), "expected background operation error, got %v", err)
+		assert.Equal(t, 1, calls, "expected 1 call, got %d", calls)
+	})
+}
+
+func TestCompareServerVersions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		a, b    string
+		want    int
+		wantErr bool
+	}{
+		{"4.4.1", "4.2", 1, false},
+		{"4.2.0", "4.2", 0, false},
+		{"4.2", "4.2.0", 0, false},
+		{"4.0.28", "4.2", -1, false},
+		{"10.0", "9.9.9", 1, false},
+		{"7.0.0-rc1", "7.0", 0, false},
+		{"5.3", "5.3.0+build", 0, false},
+		{"4.x", "4.2", 0, true},
+		{"4.2", "", 0, true},
+	}
+	for _, tc := range testCases {
+		got, err := compareServerVersions(tc.a, tc.b)
+		if tc.wantErr {
+			assert.NotNil(t, err, "expected compareServerVersions(%q, %q) error, got nil", tc.a, tc.b)
+			continue
+		}
+		require.NoError(t, err, "compareServerVersions(%q, %q) error", tc.a, tc.b)
+		switch {
+		case tc.want < 0:
+			assert.True(t, got < 0, "expected %q to be older than %q, got %d", tc.a, tc.b, got)
+		case tc.want > 0:
+			assert.True(t, got > 0, "expected %q to be newer than %q, got %d", tc.a, tc.b, got)
+		default:
+			assert.Equal(t, 0, got, "expected %q to equal %q, got %d", tc.a, tc.b, got)
+		}
+	}
+}
+
+func TestIndexModelJSON(t *testing.T) {
+	t.Parallel()
+
+	expireAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	testCases := []struct {
+		name  string
+		model IndexModel
+		json  string
+	}{
+		{
+			name:  "compound",
+			model: IndexModel{Keys: bson.D{{"b", 1}, {"a", -1}}},
+			json:  `{"key":{"b":1,"a":-1}}`,
+		},
+		{
+			name: "text",
+			model: IndexModel{
+				Keys: bson.D{{"title", "text"}, {"body", "text"}},
+				Options: options.Index().
+					SetName("search").
+					SetWeights(bson.D{{"title", 10}, {"body", 1}}).
+					SetDefaultLanguage("english"),
+			},
+			json: `{"key":{"title":"text","body":"text"},"name":"search","default_language":"english",` +
+				`"weights":{"title":10,"body":1}}`,
+		},
+		{
+			name:  "TTL",
+			model: IndexModel{Keys: bson.D{{"createdAt", 1}}, Options: options.Index().SetExpireAfterSeconds(3600)},
+			json:  `{"key":{"createdAt":1},"expireAfterSeconds":3600}`,
+		},
+		{
+			name: "partial",
+			model: IndexModel{
+				Keys: bson.D{{"email", 1}},
+				Options: options.Index().
+					SetUnique(true).
+					SetPartialFilterExpression(bson.D{{"expiresAt", bson.D{{"$gt", expireAt}}}}),
+			},
+			json: `{"key":{"email":1},"unique":true,` +
+				`"partialFilterExpression":{"expiresAt":{"$gt":{"$date":"2024-01-02T03:04:05Z"}}}}`,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // Capture range variable.
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := MarshalIndexModelJSON(tc.model)
+			require.NoError(t, err, "MarshalIndexModelJSON error")
+			assert.Equal(t, tc.json, string(data), "expected JSON %s, got %s", tc.json, data)
+
+			model, err := UnmarshalIndexModelJSON(data)
+			require.NoError(t, err, "UnmarshalIndexModelJSON error")
+
+			want, err := marshal(tc.model.Keys, nil, nil)
+			require.NoError(t, err, "marshal error")
+			got, err := marshal(model.Keys, nil, nil)
+			require.NoError(t, err, "marshal error")
+			assert.Equal(t, bson.Raw(want), bson.Raw(got), "expected keys %v, got %v", bson.Raw(want), bson.Raw(got))
+
+			again, err := MarshalIndexModelJSON(model)
+			require.NoError(t, err, "MarshalIndexModelJSON error")
+			assert.Equal(t, string(data), string(again), "expected round-tripped JSON %s, got %s", data, again)
+		})
+	}
+
+	t.Run("unknown options", func(t *testing.T) {
+		t.Parallel()
+
+		model, err := UnmarshalIndexModelJSON([]byte(`{"key": {"a": 1}, "v": 2, "background": true, "clustered": true}`))
+		var unknownErr UnknownIndexOptionsError
+		require.True(t, errors.As(err, &unknownErr), "expected UnknownIndexOptionsError, got %v", err)
+		assert.Equal(t, []string{"clustered"}, unknownErr.Fields, "expected unknown fields %v, got %v",
+			[]string{"clustered"}, unknownErr.Fields)
+		assert.Equal(t, int32(2), *model.Options.Version, "expected version 2, got %d", *model.Options.Version)
+		assert.True(t, *model.Options.Background, "expected background to be set")
+	})
+	t.Run("missing keys", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := UnmarshalIndexModelJSON([]byte(`{"name": "a_1"}`))
+		assert.NotNil(t, err, "expected UnmarshalIndexModelJSON error, got nil")
+	})
+	t.Run("numeric booleans", func(t *testing.T) {
+		t.Parallel()
+
+		model, err := UnmarshalIndexModelJSON([]byte(`{"key": {"a": 1}, "unique": 1, "sparse": 0, "background": 1}`))
+		require.NoError(t, err, "UnmarshalIndexModelJSON error")
+		assert.True(t, *model.Options.Unique, "expected unique to be set")
+		assert.False(t, *model.Options.Sparse, "expected sparse to be false")
+		assert.True(t, *model.Options.Background, "expected background to be set")
+	})
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+
+		testCases := []struct {
+			name       string
+			json       string
+			wantOption string
+		}{
+			{"non-string name", `{"key": {"a": 1}, "name": 5}`, "name"},
+			{"string unique", `{"key": {"a": 1}, "unique": "yes"}`, "unique"},
+			{"non-document key", `{"key": "a"}`, "key"},
+			{"non-document partial filter", `{"key": {"a": 1}, "partialFilterExpression": true}`,
+				"partialFilterExpression"},
+			{"non-numeric expiry", `{"key": {"a": 1}, "expireAfterSeconds": "1h"}`, "expireAfterSeconds"},
+			{"string version", `{"key": {"a": 1}, "v": "2"}`, "v"},
+			{"string background", `{"key": {"a": 1}, "background": "yes"}`, "background"},
+			{"malformed JSON", `{"key": {"a": 1}`, ""},
+			{"array", `[{"key": {"a": 1}}]`, ""},
+		}
+		for _, tc := range testCases {
+			_, err := UnmarshalIndexModelJSON([]byte(tc.json))
+			assert.NotNil(t, err, "%s: expected UnmarshalIndexModelJSON error, got nil", tc.name)
+			if tc.wantOption == "" {
+				continue
+			}
+			var typeErr IndexOptionTypeError
+			if assert.True(t, errors.As(err, &typeErr), "%s: expected IndexOptionTypeError, got %v", tc.name, err) {
+				assert.Equal(t, tc.wantOption, typeErr.Option, "%s: expected option %q, got %q", tc.name,
+					tc.wantOption, typeErr.Option)
+			}
+		}
+	})
+}
+
+func TestIndexDescription(t *testing.T) {
+	t.Parallel()
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+
+		testCases := []struct {
+			name        string
+			model       IndexModel
+			description string
+			wantName    string
+		}{
+			{"generated name", AscendingIndex("email"), "login lookups", "email_1#desc:login lookups"},
+			{
+				"explicit name",
+				IndexModel{Keys: bson.D{{"a", 1}}, Options: options.Index().SetName("by_a")},
+				"reports",
+				"by_a#desc:reports",
+			},
+			{
+				"explicit name with separator",
+				IndexModel{Keys: bson.D{{"a", 1}}, Options: options.Index().SetName("orders#v2")},
+				"reports",
+				"orders#v2#desc:reports",
+			},
+			{
+				"escaped characters",
+				AscendingIndex("a"),
+				"100% of #desc:reports",
+				"a_1#desc:100%25 of %23desc:reports",
+			},
+			{
+				"replaces description",
+				IndexModel{Keys: bson.D{{"a", 1}}, Options: options.Index().SetName("a_1#desc:old")},
+				"new",
+				"a_1#desc:new",
+			},
+			{"unicode", AscendingIndex("a"), "índice de búsqueda", "a_1#desc:índice de búsqueda"},
+		}
+		for _, tc := range testCases {
+			tc := tc // Capture range variable.
+
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				model, err := WithIndexDescription(tc.model, tc.description)
+				require.NoError(t, err, "WithIndexDescription error")
+				assert.Equal(t, tc.wantName, *model.Options.Name, "expected name %q, got %q", tc.wantName,
+					*model.Options.Name)
+
+				description, ok := IndexDescription(&IndexSpecification{Name: *model.Options.Name})
+				assert.True(t, ok, "expected a description in %q", *model.Options.Name)
+				assert.Equal(t, tc.description, description, "expected description %q, got %q", tc.description,
+					description)
+			})
+		}
+	})
+	t.Run("does not modify model", func(t *testing.T) {
+		t.Parallel()
+
+		model := UniqueIndex("email")
+		described, err := WithIndexDescription(model, "login lookups")
+		require.NoError(t, err, "WithIndexDescription error")
+		assert.Nil(t, model.Options.Name, "expected original model to have no name, got %q", model.Options.Name)
+		assert.True(t, *described.Options.Unique, "expected described model to keep the unique option")
+	})
+	t.Run("length limits", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := WithIndexDescription(AscendingIndex("a"), strings.Repeat("x", maxIndexDescriptionLength))
+		assert.Nil(t, err, "WithIndexDescription error: %v", err)
+
+		_, err = WithIndexDescription(AscendingIndex("a"), strings.Repeat("x", maxIndexDescriptionLength+1))
+		assert.NotNil(t, err, "expected error for a long description, got nil")
+
+		// Each "#" is encoded as three bytes.
+		_, err = WithIndexDescription(AscendingIndex("a"), strings.Repeat("#", maxIndexDescriptionLength/3+1))
+		assert.NotNil(t, err, "expected error for a long encoded description, got nil")
+
+		_, err = WithIndexDescription(AscendingIndex(strings.Repeat("a", 100)), strings.Repeat("x", 30))
+		assert.NotNil(t, err, "expected error for a long index name, got nil")
+	})
+	t.Run("invalid descriptions", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := WithIndexDescription(AscendingIndex("a"), "")
+		assert.NotNil(t, err, "expected error for an empty description, got nil")
+		_, err = WithIndexDescription(AscendingIndex("a"), "a\x00b")
+		assert.NotNil(t, err, "expected error for a description with a null byte, got nil")
+	})
+	t.Run("names without descriptions", func(t *testing.T) {
+		t.Parallel()
+
+		for _, name := range []string{"a_1", "a_1#desc:", "a_1#desc:%zz", "orders#v2"} {
+			_, ok := IndexDescription(&IndexSpecification{Name: name})
+			assert.False(t, ok, "expected no description in %q", name)
+		}
+		_, ok := IndexDescription(nil)
+		assert.False(t, ok, "expected no description for a nil specification")
+	})
+}
+
+func TestIndexModelDuplicates(t *testing.T) {
+	t.Parallel()
+
+	iv := IndexView{coll: &Collection{}}
+
+	testCases := []struct {
+		name          string
+		models        []IndexModel
+		wantPositions []int
+		wantErr       bool
+	}{
+		{
+			name:          "distinct",
+			models:        []IndexModel{AscendingIndex("a"), DescendingIndex("a")},
+			wantPositions: []int{0, 1},
+		},
+		{
+			name: "identical",
+			models: []IndexModel{
+				UniqueIndex("a"),
+				AscendingIndex("b"),
+				{Keys: bson.D{{"a", int64(1)}}, Options: options.Index().SetUnique(true)},
+			},
+			wantPositions: []int{0, 1, 0},
+		},
+		{
+			name:    "same name with different options",
+			models:  []IndexModel{AscendingIndex("a"), UniqueIndex("a")},
+			wantErr: true,
+		},
+		{
+			name: "same name with different keys",
+			models: []IndexModel{
+				{Keys: bson.D{{"a", 1}}, Options: options.Index().SetName("idx")},
+				{Keys: bson.D{{"b", 1}}, Options: options.Index().SetName("idx")},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // Capture range variable.
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var names []string
+			var keysDocs, optsDocs []bsoncore.Document
+			var positions []int
+			var findErr bool
+			for _, model := range tc.models {
+				keys, err := marshal(model.Keys, nil, nil)
+				require.NoError(t, err, "marshal error")
+				name, err := getOrGenerateIndexName(keys, model)
+				require.NoError(t, err, "getOrGenerateIndexName error")
+				opts := options.MergeIndexOptions(model.Options).SetName(name)
+				optsDoc, err := iv.createOptionsDoc(opts)
+				require.NoError(t, err, "createOptionsDoc error")
+
+				prev, identical := findIndexModel(name, keys, optsDoc, names, keysDocs, optsDocs)
+				if prev >= 0 {
+					if !identical {
+						findErr = true
+						break
+					}
+					positions = append(positions, prev)
+					continue
+				}
+				names = append(names, name)
+				keysDocs = append(keysDocs, keys)
+				optsDocs = append(optsDocs, optsDoc)
+				positions = append(positions, len(names)-1)
+			}
+
+			desired, err := iv.desiredIndexes(tc.models)
+			if tc.wantErr {
+				assert.True(t, findErr, "expected findIndexModel to report a conflicting model")
+				assert.NotNil(t, err, "expected desiredIndexes error, got nil")
+				return
+			}
+			assert.False(t, findErr, "expected findIndexModel to report no conflicting model")
+			assert.Equal(t, tc.wantPositions, positions, "expected positions %v, got %v", tc.wantPositions, positions)
+			require.NoError(t, err, "desiredIndexes error")
+			assert.Equal(t, len(names), len(desired), "expected %d desired indexes, got %d", len(names), len(desired))
+		})
+	}
+}
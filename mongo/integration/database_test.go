@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -342,6 +343,72 @@ func TestDatabase(t *testing.T) {
 		})
 	})
 
+	mt.RunOpts("all indexes", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		collectionsResponse := func(mt *mtest.T) bson.D {
+			return mtest.CreateCursorResponse(0, mt.DB.Name()+".$cmd.listCollections", mtest.FirstBatch,
+				bson.D{{"name", "users"}, {"type", "collection"}},
+				bson.D{{"name", "orders"}, {"type", "collection"}},
+				bson.D{{"name", "recent_orders"}, {"type", "view"}},
+				bson.D{{"name", "system.profile"}, {"type", "collection"}},
+			)
+		}
+		indexesResponse := func(mt *mtest.T, coll string, names ...string) bson.D {
+			specs := make([]bson.D, 0, len(names))
+			for _, name := range names {
+				specs = append(specs, bson.D{{"v", 2}, {"key", bson.D{{strings.TrimSuffix(name, "_1"), 1}}}, {"name", name}})
+			}
+			return mtest.CreateCursorResponse(0, mt.DB.Name()+"."+coll, mtest.FirstBatch, specs...)
+		}
+		listIndexesTargets := func(mt *mtest.T) []string {
+			var targets []string
+			for _, evt := range mt.GetAllStartedEvents() {
+				if evt.CommandName == "listIndexes" {
+					targets = append(targets, evt.Command.Lookup("listIndexes").StringValue())
+				}
+			}
+			return targets
+		}
+
+		mt.Run("skips views and system collections", func(mt *mtest.T) {
+			mt.AddMockResponses(
+				collectionsResponse(mt),
+				indexesResponse(mt, "users", "_id_", "email_1"),
+				indexesResponse(mt, "orders", "_id_"),
+			)
+
+			indexes, err := mt.DB.AllIndexes(context.Background())
+			assert.Nil(mt, err, "AllIndexes error: %v", err)
+			assert.Equal(mt, 2, len(indexes), "expected indexes for 2 collections, got %v", indexes)
+			assert.Equal(mt, 2, len(indexes["users"]), "expected 2 indexes on users, got %v", indexes["users"])
+			assert.Equal(mt, "email_1", indexes["users"][1].Name, "expected index email_1, got %q",
+				indexes["users"][1].Name)
+			assert.Equal(mt, 1, len(indexes["orders"]), "expected 1 index on orders, got %v", indexes["orders"])
+
+			targets := listIndexesTargets(mt)
+			assert.Equal(mt, []string{"users", "orders"}, targets, "expected listIndexes for users and orders, got %v",
+				targets)
+		})
+		mt.Run("include system collections", func(mt *mtest.T) {
+			mt.AddMockResponses(
+				collectionsResponse(mt),
+				indexesResponse(mt, "users", "_id_"),
+				indexesResponse(mt, "orders", "_id_"),
+				indexesResponse(mt, "system.profile"),
+			)
+
+			opts := options.AllIndexes().SetIncludeSystemCollections(true)
+			indexes, err := mt.DB.AllIndexes(context.Background(), opts)
+			assert.Nil(mt, err, "AllIndexes error: %v", err)
+			assert.Equal(mt, 3, len(indexes), "expected indexes for 3 collections, got %v", indexes)
+			_, ok := indexes["system.profile"]
+			assert.True(mt, ok, "expected system.profile to be included, got %v", indexes)
+
+			targets := listIndexesTargets(mt)
+			assert.Equal(mt, []string{"users", "orders", "system.profile"}, targets,
+				"expected listIndexes for users, orders, and system.profile, got %v", targets)
+		})
+	})
+
 	mt.RunOpts("run command cursor", noClientOpts, func(mt *mtest.T) {
 		var data []interface{}
 		for i := 0; i < 5; i++ {
@@ -9,15 +9,23 @@ package integration
 import (
 	"context"
 	"errors"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/internal/assert"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
@@ -61,6 +69,17 @@ func TestIndexView(t *testing.T) {
 				Name: "_id_",
 			})
 		})
+		mt.Run("server address", func(mt *mtest.T) {
+			cursor, err := mt.Coll.Indexes().List(context.Background())
+			assert.Nil(mt, err, "List error: %v", err)
+			defer cursor.Close(context.Background())
+
+			addr := cursor.ServerAddress()
+			assert.NotEqual(mt, "", string(addr), "expected a server address")
+			connID := mt.GetStartedEvent().ConnectionID
+			assert.True(mt, strings.HasPrefix(connID, string(addr)+"["),
+				"expected listIndexes to be sent to %q, got connection %q", addr, connID)
+		})
 		mt.Run("getMore commands are monitored", func(mt *mtest.T) {
 			createIndexes(mt, 2)
 			assertGetMoreCommandsAreMonitored(mt, cmdName, func() (*mongo.Cursor, error) {
@@ -73,6 +92,39 @@ func TestIndexView(t *testing.T) {
 				return mt.Coll.Indexes().List(context.Background(), options.ListIndexes().SetBatchSize(2))
 			})
 		})
+		mt.Run("read concern", func(mt *mtest.T) {
+			rcOpts := func(rc *readconcern.ReadConcern) *mtest.Options {
+				return mtest.NewOptions().CollectionOptions(options.Collection().SetReadConcern(rc))
+			}
+
+			mt.RunOpts("local attached on 4.4 and above", rcOpts(readconcern.Local()).MinServerVersion("4.4"), func(mt *mtest.T) {
+				_, err := mt.Coll.Indexes().List(context.Background())
+				assert.Nil(mt, err, "List error: %v", err)
+
+				evt := mt.GetStartedEvent()
+				assert.Equal(mt, "listIndexes", evt.CommandName, "expected %q command to be sent, got %q",
+					"listIndexes", evt.CommandName)
+				level, ok := evt.Command.Lookup("readConcern", "level").StringValueOK()
+				assert.True(mt, ok, "expected command %v to contain a read concern level", evt.Command)
+				assert.Equal(mt, "local", level, "expected read concern level %q, got %q", "local", level)
+			})
+			mt.RunOpts("other levels omitted", rcOpts(readconcern.Majority()).MinServerVersion("4.4"), func(mt *mtest.T) {
+				_, err := mt.Coll.Indexes().List(context.Background())
+				assert.Nil(mt, err, "List error: %v", err)
+
+				evt := mt.GetStartedEvent()
+				_, err = evt.Command.LookupErr("readConcern")
+				assert.NotNil(mt, err, "expected command %v to not contain a read concern", evt.Command)
+			})
+			mt.RunOpts("omitted below 4.4", rcOpts(readconcern.Local()).MinServerVersion("3.0").MaxServerVersion("4.2"), func(mt *mtest.T) {
+				_, err := mt.Coll.Indexes().List(context.Background())
+				assert.Nil(mt, err, "List error: %v", err)
+
+				evt := mt.GetStartedEvent()
+				_, err = evt.Command.LookupErr("readConcern")
+				assert.NotNil(mt, err, "expected command %v to not contain a read concern", evt.Command)
+			})
+		})
 	})
 	mt.RunOpts("create one", noClientOpts, func(mt *mtest.T) {
 		mt.Run("name not specified", func(mt *mtest.T) {
@@ -209,12 +261,138 @@ func TestIndexView(t *testing.T) {
 				Value: true,
 			})
 		})
+		mt.Run("verify hidden", func(mt *mtest.T) {
+			model := mongo.IndexModel{Keys: bson.D{{"x", 1}}, Options: options.Index().SetHidden(true)}
+			opts := options.CreateIndexes().SetVerifyHidden(true)
+
+			mt.RunOpts("supported", mtest.NewOptions().MinServerVersion("4.4"), func(mt *mtest.T) {
+				_, err := mt.Coll.Indexes().CreateOne(context.Background(), model, opts)
+				assert.Nil(mt, err, "CreateOne error: %v", err)
+			})
+			mt.RunOpts("ignored by server", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+				ns := mt.DB.Name() + "." + mt.Coll.Name()
+				mt.AddMockResponses(
+					mtest.CreateSuccessResponse(bson.E{"numIndexesBefore", 1}, bson.E{"numIndexesAfter", 2}),
+					mtest.CreateCursorResponse(0, ns, mtest.FirstBatch,
+						bson.D{{"v", 2}, {"key", bson.D{{"_id", 1}}}, {"name", "_id_"}},
+						bson.D{{"v", 2}, {"key", bson.D{{"x", 1}}}, {"name", "x_1"}},
+					),
+				)
+
+				_, err := mt.Coll.Indexes().CreateOne(context.Background(), model, opts)
+				assert.NotNil(mt, err, "expected CreateOne error, got nil")
+				assert.True(mt, strings.Contains(err.Error(), `"x_1"`), "expected error %q to name the index", err)
+			})
+		})
+		mt.Run("verify names", func(mt *mtest.T) {
+			iv := mt.Coll.Indexes()
+			opts := options.CreateIndexes().SetVerifyNames(true)
+			names, err := iv.CreateMany(context.Background(), []mongo.IndexModel{
+				{Keys: bson.D{{"title", "text"}, {"body", "text"}}},
+				{Keys: bson.D{{"foo", 1}}},
+			}, opts)
+			assert.Nil(mt, err, "CreateMany error: %v", err)
+
+			specs, err := iv.ListSpecifications(context.Background())
+			assert.Nil(mt, err, "ListSpecifications error: %v", err)
+			serverNames := make(map[string]bool, len(specs))
+			for _, spec := range specs {
+				serverNames[spec.Name] = true
+			}
+			for _, name := range names {
+				assert.True(mt, serverNames[name], "expected name %q to be reported by the server, got %v", name, specs)
+			}
+
+			_, err = iv.DropOne(context.Background(), names[0])
+			assert.Nil(mt, err, "DropOne error: %v", err)
+		})
+		mt.Run("reserved key fields", func(mt *mtest.T) {
+			for _, keys := range []bson.D{{{"$natural", 1}}, {{"$foo", 1}}, {}} {
+				mt.ClearEvents()
+				_, err := mt.Coll.Indexes().CreateOne(context.Background(), mongo.IndexModel{Keys: keys})
+				assert.NotNil(mt, err, "expected CreateOne error for keys %v, got nil", keys)
+				assert.Nil(mt, mt.GetStartedEvent(), "expected no command to be sent for keys %v", keys)
+			}
+		})
+		mt.Run("validate key values", func(mt *mtest.T) {
+			opts := options.CreateIndexes().SetValidateKeyValues(true)
+			_, err := mt.Coll.Indexes().CreateOne(context.Background(), mongo.IndexModel{Keys: bson.D{{"foo", 0}}}, opts)
+			assert.NotNil(mt, err, "expected CreateOne error, got nil")
+			assert.Nil(mt, mt.GetStartedEvent(), "expected no command to be sent")
+
+			name, err := mt.Coll.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+				Keys: bson.D{{"foo", 1}, {"bar", -1}},
+			}, opts)
+			assert.Nil(mt, err, "CreateOne error: %v", err)
+			assert.Equal(mt, "foo_1_bar_-1", name, "expected name %q, got %q", "foo_1_bar_-1", name)
+		})
+		mt.Run("min server version", func(mt *mtest.T) {
+			model := mongo.IndexModel{Keys: bson.D{{"foo", 1}}}
+
+			mt.Run("server too old", func(mt *mtest.T) {
+				opts := options.CreateIndexes().SetMinServerVersion("99.0")
+				_, err := mt.Coll.Indexes().CreateOne(context.Background(), model, opts)
+				var sve mongo.ServerVersionError
+				assert.True(mt, errors.As(err, &sve), "expected ServerVersionError, got %v", err)
+				assert.Equal(mt, "99.0", sve.MinVersion, "expected MinVersion %q, got %q", "99.0", sve.MinVersion)
+
+				for _, evt := range mt.GetAllStartedEvents() {
+					assert.NotEqual(mt, "createIndexes", evt.CommandName, "expected createIndexes not to be sent")
+				}
+			})
+			mt.Run("server new enough", func(mt *mtest.T) {
+				opts := options.CreateIndexes().SetMinServerVersion("3.6")
+				name, err := mt.Coll.Indexes().CreateOne(context.Background(), model, opts)
+				assert.Nil(mt, err, "CreateOne error: %v", err)
+				assert.Equal(mt, "foo_1", name, "expected name %q, got %q", "foo_1", name)
+			})
+			mt.Run("invalid version", func(mt *mtest.T) {
+				opts := options.CreateIndexes().SetMinServerVersion("latest")
+				_, err := mt.Coll.Indexes().CreateOne(context.Background(), model, opts)
+				assert.NotNil(mt, err, "expected CreateOne error, got nil")
+				assert.Nil(mt, mt.GetStartedEvent(), "expected no command to be sent")
+			})
+		})
+		mt.RunOpts("shard through mongos", mtest.NewOptions().Topologies(mtest.Sharded), func(mt *mtest.T) {
+			opts := options.CreateIndexes().SetShard("shard01")
+			_, err := mt.Coll.Indexes().CreateOne(context.Background(), mongo.IndexModel{Keys: bson.D{{"foo", 1}}}, opts)
+			assert.True(mt, errors.Is(err, mongo.ErrShardRequiresDirectConnection),
+				"expected error %v, got %v", mongo.ErrShardRequiresDirectConnection, err)
+			assert.Nil(mt, mt.GetStartedEvent(), "expected no command to be sent")
+		})
 		mt.Run("nil keys", func(mt *mtest.T) {
 			_, err := mt.Coll.Indexes().CreateOne(context.Background(), mongo.IndexModel{
 				Keys: nil,
 			})
 			assert.NotNil(mt, err, "expected CreateOne error, got nil")
 		})
+		mt.Run("foreground", func(mt *mtest.T) {
+			foreground := options.CreateIndexes().SetForeground(true)
+			model := mongo.IndexModel{Keys: bson.D{{"x", 1}}}
+
+			mt.RunOpts("supported before 4.2", mtest.NewOptions().MaxServerVersion("4.0"), func(mt *mtest.T) {
+				mt.ClearEvents()
+				_, err := mt.Coll.Indexes().CreateOne(context.Background(), model, foreground)
+				assert.Nil(mt, err, "CreateOne error: %v", err)
+
+				cmd := mt.GetStartedEvent().Command
+				background, err := cmd.LookupErr("indexes", "0", "background")
+				assert.Nil(mt, err, "expected background in command %s", cmd)
+				assert.False(mt, background.Boolean(), "expected background to be false, got true")
+			})
+			mt.RunOpts("error on 4.2 and above", mtest.NewOptions().MinServerVersion("4.2"), func(mt *mtest.T) {
+				_, err := mt.Coll.Indexes().CreateOne(context.Background(), model, foreground)
+				assert.NotNil(mt, err, "expected CreateOne error, got nil")
+			})
+			mt.Run("conflicts with background", func(mt *mtest.T) {
+				bgModel := mongo.IndexModel{
+					Keys:    bson.D{{"y", 1}},
+					Options: options.Index().SetBackground(true),
+				}
+				_, err := mt.Coll.Indexes().CreateOne(context.Background(), bgModel, foreground)
+				assert.NotNil(mt, err, "expected CreateOne error, got nil")
+			})
+		})
 		// Only run on replica sets as commitQuorum is not supported on standalones.
 		mt.RunOpts("commit quorum", mtest.NewOptions().Topologies(mtest.ReplicaSet).CreateClient(false), func(mt *mtest.T) {
 			intVal := options.CreateIndexes().SetCommitQuorumInt(1)
@@ -264,6 +442,13 @@ func TestIndexView(t *testing.T) {
 				})
 			}
 		})
+		mt.Run("invalid commit quorum string", func(mt *mtest.T) {
+			mt.ClearEvents()
+			opts := options.CreateIndexes().SetCommitQuorumString("MAJORITY")
+			_, err := mt.Coll.Indexes().CreateOne(context.Background(), mongo.IndexModel{Keys: bson.D{{"x", 1}}}, opts)
+			assert.NotNil(mt, err, "expected CreateOne error, got nil")
+			assert.Nil(mt, mt.GetStartedEvent(), "expected no command to be sent")
+		})
 		unackClientOpts := options.Client().
 			SetWriteConcern(writeconcern.New(writeconcern.W(0)))
 		unackMtOpts := mtest.NewOptions().
@@ -448,6 +633,540 @@ func TestIndexView(t *testing.T) {
 			assert.Equal(mt, int32(100), cmdErr.Code, "expected error code 100, got %v", cmdErr.Code)
 
 		})
+		mt.RunOpts("error attributed to model", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{
+				Code:    16755,
+				Name:    "Location16755",
+				Message: `Error in specification { key: { loc: "2dsphere" }, name: "loc_2dsphere" } :: caused by :: Can't extract geo keys`,
+			}))
+
+			_, err := mt.Coll.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+				{Keys: bson.D{{"foo", 1}}},
+				{Keys: bson.D{{"bar", 1}}},
+				{Keys: bson.D{{"baz", -1}}},
+				{Keys: bson.D{{"loc", "2dsphere"}}},
+			})
+			assert.NotNil(mt, err, "expected CreateMany error, got nil")
+
+			var modelErr mongo.IndexModelError
+			assert.True(mt, errors.As(err, &modelErr), "expected mongo.IndexModelError, got %T", err)
+			assert.Equal(mt, 3, modelErr.Index, "expected model index 3, got %v", modelErr.Index)
+			assert.Equal(mt, "loc_2dsphere", modelErr.Name, "expected name %q, got %q", "loc_2dsphere", modelErr.Name)
+
+			var cmdErr mongo.CommandError
+			assert.True(mt, errors.As(err, &cmdErr), "expected error to wrap mongo.CommandError, got %T", err)
+			assert.Equal(mt, int32(16755), cmdErr.Code, "expected error code 16755, got %v", cmdErr.Code)
+		})
+		mt.RunOpts("duplicate key conflict", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+			res := mtest.CreateCommandErrorResponse(mtest.CommandError{
+				Code:    11000,
+				Name:    "DuplicateKey",
+				Message: `Index build failed: E11000 duplicate key error collection: db.coll index: bar_1 dup key: { bar: 2 }`,
+			})
+			res = append(res,
+				bson.E{"keyPattern", bson.D{{"bar", 1}}},
+				bson.E{"keyValue", bson.D{{"bar", 2}}},
+			)
+			mt.AddMockResponses(res)
+
+			_, err := mt.Coll.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+				{Keys: bson.D{{"foo", 1}}},
+				{Keys: bson.D{{"bar", 1}}, Options: options.Index().SetUnique(true)},
+			})
+			assert.NotNil(mt, err, "expected CreateMany error, got nil")
+
+			var conflict mongo.DuplicateKeyConflict
+			assert.True(mt, errors.As(err, &conflict), "expected mongo.DuplicateKeyConflict, got %T", err)
+			wantPattern := bson.Raw(bsoncore.NewDocumentBuilder().AppendInt32("bar", 1).Build())
+			assert.Equal(mt, wantPattern, conflict.KeyPattern, "expected key pattern %v, got %v", wantPattern,
+				conflict.KeyPattern)
+			wantValue := bson.Raw(bsoncore.NewDocumentBuilder().AppendInt32("bar", 2).Build())
+			assert.Equal(mt, wantValue, conflict.KeyValue, "expected key value %v, got %v", wantValue, conflict.KeyValue)
+
+			var modelErr mongo.IndexModelError
+			assert.True(mt, errors.As(err, &modelErr), "expected mongo.IndexModelError, got %T", err)
+			assert.Equal(mt, 1, modelErr.Index, "expected model index 1, got %v", modelErr.Index)
+			assert.True(mt, mongo.IsDuplicateKeyError(err), "expected error %v to be a duplicate key error", err)
+		})
+		mt.RunOpts("invalid collation locale", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{
+				Code:    2,
+				Name:    "BadValue",
+				Message: `Field 'locale' is invalid in: { locale: "xx_YY", strength: 2 }`,
+			}))
+
+			_, err := mt.Coll.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+				{Keys: bson.D{{"foo", 1}}, Options: options.Index().SetCollation(options.NewCollation("en"))},
+				{Keys: bson.D{{"bar", 1}}, Options: options.Index().SetCollation(options.NewCollation("xx_YY").SetStrength(2))},
+			})
+
+			var modelErr mongo.IndexModelError
+			assert.True(mt, errors.As(err, &modelErr), "expected IndexModelError, got %v", err)
+			assert.Equal(mt, 1, modelErr.Index, "expected models[1] to be reported, got %d", modelErr.Index)
+
+			var localeErr mongo.InvalidCollationLocaleError
+			assert.True(mt, errors.As(err, &localeErr), "expected InvalidCollationLocaleError, got %v", err)
+			assert.Equal(mt, "xx_YY", localeErr.Locale, "expected locale %q, got %q", "xx_YY", localeErr.Locale)
+
+			var cmdErr mongo.CommandError
+			assert.True(mt, errors.As(err, &cmdErr), "expected error to wrap mongo.CommandError, got %T", err)
+		})
+		mt.RunOpts("view rejected by server", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{
+				Code:    166,
+				Name:    "CommandNotSupportedOnView",
+				Message: "Namespace db.view is a view, not a collection",
+			}))
+
+			_, err := mt.Coll.Indexes().CreateOne(context.Background(), mongo.IndexModel{Keys: bson.D{{"foo", 1}}})
+			assert.True(mt, errors.Is(err, mongo.ErrIndexOnView), "expected error %v, got %v", mongo.ErrIndexOnView, err)
+
+			var cmdErr mongo.CommandError
+			assert.True(mt, errors.As(err, &cmdErr), "expected error to wrap mongo.CommandError, got %T", err)
+			assert.Equal(mt, int32(166), cmdErr.Code, "expected error code 166, got %v", cmdErr.Code)
+		})
+		mt.RunOpts("with result", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+			models := []mongo.IndexModel{{Keys: bson.D{{"foo", 1}}}}
+
+			mt.Run("write concern satisfied", func(mt *mtest.T) {
+				mt.AddMockResponses(bson.D{
+					{"ok", 1},
+					{"createdCollectionAutomatically", true},
+					{"numIndexesBefore", 1},
+					{"numIndexesAfter", 2},
+				})
+
+				res, err := mt.Coll.Indexes().CreateManyWithResult(context.Background(), models)
+				assert.Nil(mt, err, "CreateManyWithResult error: %v", err)
+				assert.Equal(mt, []string{"foo_1"}, res.Names, "expected names %v, got %v", []string{"foo_1"}, res.Names)
+				assert.True(mt, res.CreatedCollectionAutomatically, "expected CreatedCollectionAutomatically to be true")
+				assert.Equal(mt, int32(1), res.IndexesBefore, "expected IndexesBefore 1, got %v", res.IndexesBefore)
+				assert.Equal(mt, int32(2), res.IndexesAfter, "expected IndexesAfter 2, got %v", res.IndexesAfter)
+				assert.Nil(mt, res.WriteConcernError, "expected no write concern error, got %v", res.WriteConcernError)
+				assert.Nil(mt, res.DocsScanned, "expected DocsScanned to be nil, got %v", res.DocsScanned)
+				assert.Nil(mt, res.KeysInserted, "expected KeysInserted to be nil, got %v", res.KeysInserted)
+			})
+			mt.Run("build stats", func(mt *mtest.T) {
+				mt.AddMockResponses(bson.D{
+					{"ok", 1},
+					{"numIndexesBefore", 1},
+					{"numIndexesAfter", 2},
+					{"docsScanned", int64(100)},
+					{"keysInserted", int64(250)},
+				})
+
+				res, err := mt.Coll.Indexes().CreateManyWithResult(context.Background(), models)
+				assert.Nil(mt, err, "CreateManyWithResult error: %v", err)
+				assert.NotNil(mt, res.DocsScanned, "expected DocsScanned to be set")
+				assert.Equal(mt, int64(100), *res.DocsScanned, "expected DocsScanned 100, got %v", *res.DocsScanned)
+				assert.NotNil(mt, res.KeysInserted, "expected KeysInserted to be set")
+				assert.Equal(mt, int64(250), *res.KeysInserted, "expected KeysInserted 250, got %v", *res.KeysInserted)
+			})
+			mt.Run("write concern error", func(mt *mtest.T) {
+				mt.AddMockResponses(bson.D{
+					{"ok", 1},
+					{"numIndexesBefore", 1},
+					{"numIndexesAfter", 2},
+					{"writeConcernError", bson.D{
+						{"code", 64},
+						{"codeName", "WriteConcernFailed"},
+						{"errmsg", "waiting for replication timed out"},
+					}},
+				})
+
+				res, err := mt.Coll.Indexes().CreateManyWithResult(context.Background(), models)
+				var we mongo.WriteException
+				assert.True(mt, errors.As(err, &we), "expected mongo.WriteException, got %v", err)
+				assert.NotNil(mt, res, "expected a result with the write concern error")
+				assert.Equal(mt, []string{"foo_1"}, res.Names, "expected names %v, got %v", []string{"foo_1"}, res.Names)
+				assert.Equal(mt, int32(2), res.IndexesAfter, "expected IndexesAfter 2, got %v", res.IndexesAfter)
+				assert.NotNil(mt, res.WriteConcernError, "expected a write concern error")
+				assert.Equal(mt, 64, res.WriteConcernError.Code, "expected write concern error code 64, got %v",
+					res.WriteConcernError.Code)
+			})
+		})
+		mt.RunOpts("batches", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+			models := []mongo.IndexModel{
+				{Keys: bson.D{{"a", 1}}},
+				{Keys: bson.D{{"b", 1}}},
+				{Keys: bson.D{{"c", 1}}},
+				{Keys: bson.D{{"d", 1}}},
+				{Keys: bson.D{{"e", 1}}},
+			}
+			opts := options.CreateIndexes().SetBatchSize(2)
+
+			mt.Run("all batches succeed", func(mt *mtest.T) {
+				mt.AddMockResponses(
+					mtest.CreateSuccessResponse(bson.E{"numIndexesBefore", 1}, bson.E{"numIndexesAfter", 3}),
+					mtest.CreateSuccessResponse(bson.E{"numIndexesBefore", 3}, bson.E{"numIndexesAfter", 5}),
+					mtest.CreateSuccessResponse(bson.E{"numIndexesBefore", 5}, bson.E{"numIndexesAfter", 6}),
+				)
+
+				res, err := mt.Coll.Indexes().CreateManyWithResult(context.Background(), models, opts)
+				assert.Nil(mt, err, "CreateManyWithResult error: %v", err)
+				expected := []string{"a_1", "b_1", "c_1", "d_1", "e_1"}
+				assert.Equal(mt, expected, res.Names, "expected names %v, got %v", expected, res.Names)
+				assert.Equal(mt, int32(1), res.IndexesBefore, "expected 1 index before, got %v", res.IndexesBefore)
+				assert.Equal(mt, int32(6), res.IndexesAfter, "expected 6 indexes after, got %v", res.IndexesAfter)
+
+				for _, want := range []int{2, 2, 1} {
+					evt := mt.GetStartedEvent()
+					values, err := evt.Command.Lookup("indexes").Array().Values()
+					assert.Nil(mt, err, "Values error: %v", err)
+					assert.Equal(mt, want, len(values), "expected %d indexes in command, got %d", want, len(values))
+				}
+			})
+			mt.Run("mid-batch failure", func(mt *mtest.T) {
+				mt.AddMockResponses(
+					mtest.CreateSuccessResponse(bson.E{"numIndexesBefore", 1}, bson.E{"numIndexesAfter", 3}),
+					mtest.CreateCommandErrorResponse(mtest.CommandError{
+						Code:    67,
+						Name:    "CannotCreateIndex",
+						Message: `cannot create index "d_1"`,
+					}),
+				)
+
+				_, err := mt.Coll.Indexes().CreateMany(context.Background(), models, opts)
+				var partialErr mongo.PartialIndexCreationError
+				assert.True(mt, errors.As(err, &partialErr), "expected PartialIndexCreationError, got %v", err)
+				expected := []string{"a_1", "b_1"}
+				assert.Equal(mt, expected, partialErr.Created, "expected created names %v, got %v", expected,
+					partialErr.Created)
+
+				var modelErr mongo.IndexModelError
+				assert.True(mt, errors.As(err, &modelErr), "expected IndexModelError, got %v", err)
+				assert.Equal(mt, 3, modelErr.Index, "expected models[3] to be reported, got %d", modelErr.Index)
+				assert.Equal(mt, 2, len(mt.GetAllStartedEvents()), "expected the third batch to not be sent")
+			})
+			mt.Run("first batch failure", func(mt *mtest.T) {
+				mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{
+					Code:    67,
+					Name:    "CannotCreateIndex",
+					Message: "cannot create index",
+				}))
+
+				_, err := mt.Coll.Indexes().CreateMany(context.Background(), models, opts)
+				assert.NotNil(mt, err, "expected CreateMany error, got nil")
+				var partialErr mongo.PartialIndexCreationError
+				assert.False(mt, errors.As(err, &partialErr), "expected no PartialIndexCreationError, got %v", err)
+			})
+			mt.Run("invalid batch size", func(mt *mtest.T) {
+				_, err := mt.Coll.Indexes().CreateMany(context.Background(), models, options.CreateIndexes().SetBatchSize(0))
+				assert.NotNil(mt, err, "expected CreateMany error, got nil")
+			})
+		})
+		mt.RunOpts("background operation in progress", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+			model := mongo.IndexModel{Keys: bson.D{{"foo", 1}}}
+
+			mt.Run("transient failure is retried", func(mt *mtest.T) {
+				mt.AddMockResponses(
+					mtest.CreateCommandErrorResponse(mtest.CommandError{
+						Code:    12587,
+						Name:    "BackgroundOperationInProgressForNamespace",
+						Message: "cannot perform operation: a background operation is currently running for collection",
+					}),
+					mtest.CreateSuccessResponse(bson.E{"numIndexesBefore", 1}, bson.E{"numIndexesAfter", 2}),
+				)
+
+				name, err := mt.Coll.Indexes().CreateOne(context.Background(), model)
+				assert.Nil(mt, err, "CreateOne error: %v", err)
+				assert.Equal(mt, "foo_1", name, "expected name %q, got %q", "foo_1", name)
+
+				var attempts int
+				for _, evt := range mt.GetAllStartedEvents() {
+					if evt.CommandName == "createIndexes" {
+						attempts++
+					}
+				}
+				assert.Equal(mt, 2, attempts, "expected 2 createIndexes commands, got %d", attempts)
+			})
+			mt.Run("permanent failure is not retried", func(mt *mtest.T) {
+				mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{
+					Code:    67,
+					Name:    "CannotCreateIndex",
+					Message: "cannot create index",
+				}))
+
+				_, err := mt.Coll.Indexes().CreateOne(context.Background(), model)
+				assert.NotNil(mt, err, "expected CreateOne error, got nil")
+				assert.False(mt, mongo.IsBackgroundOperationInProgressError(err),
+					"expected error to not be a background operation error, got %v", err)
+
+				var attempts int
+				for _, evt := range mt.GetAllStartedEvents() {
+					if evt.CommandName == "createIndexes" {
+						attempts++
+					}
+				}
+				assert.Equal(mt, 1, attempts, "expected 1 createIndexes command, got %d", attempts)
+			})
+		})
+		mt.RunOpts("build order", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+			models := []mongo.IndexModel{
+				{Keys: bson.D{{"body", "text"}}},
+				{Keys: bson.D{{"a", 1}, {"b", 1}}},
+				{Keys: bson.D{{"c", 1}}},
+			}
+
+			testCases := []struct {
+				name  string
+				order options.IndexBuildOrder
+				want  []string
+			}{
+				{"as given", options.IndexBuildOrderAsGiven, []string{"body_text", "a_1_b_1", "c_1"}},
+				{"by cost", options.IndexBuildOrderByCost, []string{"c_1", "a_1_b_1", "body_text"}},
+			}
+			for _, tc := range testCases {
+				mt.Run(tc.name, func(mt *mtest.T) {
+					mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+					names, err := mt.Coll.Indexes().CreateMany(context.Background(), models,
+						options.CreateIndexes().SetBuildOrder(tc.order))
+					assert.Nil(mt, err, "CreateMany error: %v", err)
+					expected := []string{"body_text", "a_1_b_1", "c_1"}
+					assert.Equal(mt, expected, names, "expected names in model order %v, got %v", expected, names)
+
+					values, err := mt.GetStartedEvent().Command.Lookup("indexes").Array().Values()
+					assert.Nil(mt, err, "Values error: %v", err)
+					sent := make([]string, 0, len(values))
+					for _, v := range values {
+						sent = append(sent, v.Document().Lookup("name").StringValue())
+					}
+					assert.Equal(mt, tc.want, sent, "expected indexes to be sent in order %v, got %v", tc.want, sent)
+				})
+			}
+		})
+		mt.RunOpts("duplicate models", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+			mt.Run("identical models are sent once but named for each model", func(mt *mtest.T) {
+				mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+				names, err := mt.Coll.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+					{Keys: bson.D{{"foo", 1}}, Options: options.Index().SetUnique(true)},
+					{Keys: bson.D{{"bar", -1}}},
+					{Keys: bson.D{{"foo", int64(1)}}, Options: options.Index().SetUnique(true)},
+				})
+				assert.Nil(mt, err, "CreateMany error: %v", err)
+				expected := []string{"foo_1", "bar_-1", "foo_1"}
+				assert.Equal(mt, expected, names, "expected names %v, got %v", expected, names)
+
+				values, err := mt.GetStartedEvent().Command.Lookup("indexes").Array().Values()
+				assert.Nil(mt, err, "Values error: %v", err)
+				assert.Equal(mt, 2, len(values), "expected 2 indexes to be sent, got %d", len(values))
+			})
+			mt.Run("same name with different options", func(mt *mtest.T) {
+				_, err := mt.Coll.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+					{Keys: bson.D{{"foo", 1}}},
+					{Keys: bson.D{{"bar", -1}}},
+					{Keys: bson.D{{"foo", 1}}, Options: options.Index().SetUnique(true)},
+				})
+				var modelErr mongo.IndexModelError
+				assert.True(mt, errors.As(err, &modelErr), "expected mongo.IndexModelError, got %v", err)
+				assert.Equal(mt, 2, modelErr.Index, "expected model index 2, got %v", modelErr.Index)
+				assert.Equal(mt, "foo_1", modelErr.Name, "expected name %q, got %q", "foo_1", modelErr.Name)
+				assert.Nil(mt, mt.GetStartedEvent(), "expected no command to be sent")
+			})
+			mt.Run("errors are attributed to the original position", func(mt *mtest.T) {
+				mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{
+					Code:    16755,
+					Name:    "Location16755",
+					Message: `Error in specification { key: { loc: "2dsphere" }, name: "loc_2dsphere" } :: caused by :: Can't extract geo keys`,
+				}))
+
+				_, err := mt.Coll.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+					{Keys: bson.D{{"foo", 1}}},
+					{Keys: bson.D{{"foo", 1}}},
+					{Keys: bson.D{{"loc", "2dsphere"}}},
+				})
+				var modelErr mongo.IndexModelError
+				assert.True(mt, errors.As(err, &modelErr), "expected mongo.IndexModelError, got %v", err)
+				assert.Equal(mt, 2, modelErr.Index, "expected model index 2, got %v", modelErr.Index)
+			})
+		})
+		mt.RunOpts("named", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+			models := []mongo.IndexModel{
+				{Keys: bson.D{{"title", "text"}, {"body", "text"}}},
+				{Keys: bson.D{{"attrs.$**", 1}}},
+				{Keys: bson.D{{"a", 1}, {"b", -1}}, Options: options.Index().SetName("a_b")},
+				{Keys: bson.D{{"title", "text"}, {"body", "text"}}},
+				{Keys: bson.D{{"c", 1}}},
+			}
+			named, err := mt.Coll.Indexes().CreateManyNamed(context.Background(), models,
+				options.CreateIndexes().SetBuildOrder(options.IndexBuildOrderByCost))
+			assert.Nil(mt, err, "CreateManyNamed error: %v", err)
+
+			expected := []string{"title_text_body_text", "attrs.$**_1", "a_b", "title_text_body_text", "c_1"}
+			assert.Equal(mt, len(expected), len(named), "expected %d named models, got %d", len(expected), len(named))
+			for i, nm := range named {
+				assert.Equal(mt, expected[i], nm.Name, "expected models[%d] to be named %q, got %q", i, expected[i], nm.Name)
+				assert.Equal(mt, models[i].Keys, nm.Model.Keys, "expected models[%d] keys %v, got %v",
+					i, models[i].Keys, nm.Model.Keys)
+			}
+		})
+		mt.RunOpts("write concern override", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+			wc := writeconcern.New(writeconcern.WMajority())
+			opts := options.CreateIndexes().SetWriteConcern(wc)
+			_, err := mt.Coll.Indexes().CreateOne(context.Background(), mongo.IndexModel{Keys: bson.D{{"foo", 1}}}, opts)
+			assert.Nil(mt, err, "CreateOne error: %v", err)
+
+			w, err := mt.GetStartedEvent().Command.LookupErr("writeConcern", "w")
+			assert.Nil(mt, err, "expected writeConcern.w to be sent: %v", err)
+			assert.Equal(mt, "majority", w.StringValue(), "expected w %q, got %v", "majority", w)
+		})
+		mt.Run("timed", func(mt *mtest.T) {
+			names, elapsed, err := mt.Coll.Indexes().CreateManyTimed(context.Background(), []mongo.IndexModel{
+				{Keys: bson.D{{"foo", 1}}},
+				{Keys: bson.D{{"bar", -1}}},
+			})
+			assert.Nil(mt, err, "CreateManyTimed error: %v", err)
+			assert.Equal(mt, []string{"foo_1", "bar_-1"}, names, "expected names %v, got %v",
+				[]string{"foo_1", "bar_-1"}, names)
+			assert.True(mt, elapsed > 0, "expected a positive duration, got %v", elapsed)
+
+			_, elapsed, err = mt.Coll.Indexes().CreateManyTimed(context.Background(), []mongo.IndexModel{{Keys: nil}})
+			assert.NotNil(mt, err, "expected CreateManyTimed error, got nil")
+			assert.Equal(mt, time.Duration(0), elapsed, "expected zero duration for a client-side error, got %v", elapsed)
+		})
+		mt.RunOpts("direct connection to a secondary", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{
+				Code:    10107,
+				Name:    "NotWritablePrimary",
+				Message: "not primary",
+			}))
+
+			_, err := mt.Coll.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+				{Keys: bson.D{{"foo", 1}}},
+			})
+			assert.NotNil(mt, err, "expected CreateMany error, got nil")
+			assert.True(mt, strings.Contains(err.Error(), "direct connection to a secondary"),
+				"expected error %q to explain the direct connection", err)
+
+			var cmdErr mongo.CommandError
+			assert.True(mt, errors.As(err, &cmdErr), "expected error to wrap mongo.CommandError, got %T", err)
+			assert.Equal(mt, int32(10107), cmdErr.Code, "expected error code 10107, got %v", cmdErr.Code)
+		})
+		mt.RunOpts("partial unique conflict", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+			res := mtest.CreateCommandErrorResponse(mtest.CommandError{
+				Code:    11000,
+				Name:    "DuplicateKey",
+				Message: `Index build failed: E11000 duplicate key error collection: db.coll index: email_1 dup key: { email: "a@example.com" }`,
+			})
+			res = append(res,
+				bson.E{"keyPattern", bson.D{{"email", 1}}},
+				bson.E{"keyValue", bson.D{{"email", "a@example.com"}}},
+			)
+			mt.AddMockResponses(res)
+
+			filter := bson.D{{"active", true}}
+			_, err := mt.Coll.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+				{
+					Keys:    bson.D{{"email", 1}},
+					Options: options.Index().SetUnique(true).SetPartialFilterExpression(filter),
+				},
+			})
+			assert.NotNil(mt, err, "expected CreateMany error, got nil")
+
+			var conflict mongo.DuplicateKeyConflict
+			assert.True(mt, errors.As(err, &conflict), "expected mongo.DuplicateKeyConflict, got %T", err)
+			wantFilter, err := bson.Marshal(filter)
+			assert.Nil(mt, err, "Marshal error: %v", err)
+			assert.Equal(mt, bson.Raw(wantFilter), conflict.PartialFilterExpression,
+				"expected partial filter %v, got %v", bson.Raw(wantFilter), conflict.PartialFilterExpression)
+			wantValue := bson.Raw(bsoncore.NewDocumentBuilder().AppendString("email", "a@example.com").Build())
+			assert.Equal(mt, wantValue, conflict.KeyValue, "expected key value %v, got %v", wantValue, conflict.KeyValue)
+		})
+		mt.RunOpts("audit comment", mtest.NewOptions().MinServerVersion("4.4"), func(mt *mtest.T) {
+			marker := bson.D{{"tool", "migrator"}, {"step", int32(3)}}
+			opts := options.CreateIndexes().SetAuditComment(marker)
+			_, err := mt.Coll.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+				{Keys: bson.D{{"foo", 1}}},
+			}, opts)
+			assert.Nil(mt, err, "CreateMany error: %v", err)
+
+			evt := mt.GetStartedEvent()
+			assert.Equal(mt, "createIndexes", evt.CommandName, "expected %q command to be sent, got %q",
+				"createIndexes", evt.CommandName)
+			comment, ok := evt.Command.Lookup("comment").DocumentOK()
+			assert.True(mt, ok, "expected command %v to contain a comment document", evt.Command)
+
+			want, err := bson.Marshal(marker)
+			assert.Nil(mt, err, "Marshal error: %v", err)
+			assert.Equal(mt, bson.Raw(want), comment, "expected comment %v, got %v", bson.Raw(want), comment)
+		})
+		mt.Run("registry override", func(mt *mtest.T) {
+			type indexStatus struct{ active bool }
+			encodeStatus := func(_ bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+				if val.Interface().(indexStatus).active {
+					return vw.WriteString("active")
+				}
+				return vw.WriteString("inactive")
+			}
+			reg := bson.NewRegistry()
+			reg.RegisterTypeEncoder(reflect.TypeOf(indexStatus{}), bsoncodec.ValueEncoderFunc(encodeStatus))
+
+			model := mongo.IndexModel{
+				Keys: bson.D{{"email", 1}},
+				Options: options.Index().
+					SetUnique(true).
+					SetPartialFilterExpression(bson.D{{"status", indexStatus{active: true}}}),
+			}
+			mt.ClearEvents()
+			_, err := mt.Coll.Indexes().CreateMany(context.Background(), []mongo.IndexModel{model},
+				options.CreateIndexes().SetRegistry(reg))
+			assert.Nil(mt, err, "CreateMany error: %v", err)
+
+			cmd := mt.GetStartedEvent().Command
+			status, err := cmd.LookupErr("indexes", "0", "partialFilterExpression", "status")
+			assert.Nil(mt, err, "expected partialFilterExpression.status in command %s", cmd)
+			assert.Equal(mt, "active", status.StringValue(), "expected status %q, got %v", "active", status)
+		})
+		mt.Run("default storage engine", func(mt *mtest.T) {
+			defaultEngine := bson.D{{"wiredTiger", bson.D{{"configString", "block_compressor=zlib"}}}}
+			ownEngine := bson.D{{"wiredTiger", bson.D{{"configString", "block_compressor=snappy"}}}}
+			opts := options.CreateIndexes().SetDefaultStorageEngine(defaultEngine)
+
+			mt.ClearEvents()
+			_, err := mt.Coll.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+				{Keys: bson.D{{"foo", 1}}},
+				{Keys: bson.D{{"bar", 1}}, Options: options.Index().SetStorageEngine(ownEngine)},
+			}, opts)
+			assert.Nil(mt, err, "CreateMany error: %v", err)
+
+			cmd := mt.GetStartedEvent().Command
+			expected := []string{"block_compressor=zlib", "block_compressor=snappy"}
+			for i, want := range expected {
+				got, err := cmd.LookupErr("indexes", strconv.Itoa(i), "storageEngine", "wiredTiger", "configString")
+				assert.Nil(mt, err, "expected storageEngine for index %d in command %s", i, cmd)
+				assert.Equal(mt, want, got.StringValue(), "expected configString %q for index %d, got %v", want, i, got)
+			}
+
+			_, err = mt.Coll.Indexes().CreateMany(context.Background(), []mongo.IndexModel{{Keys: bson.D{{"baz", 1}}}},
+				options.CreateIndexes().SetDefaultStorageEngine(bson.M{"wiredTiger": bson.M{}, "inMemory": bson.M{}}))
+			assert.Equal(mt, mongo.ErrMapForOrderedArgument{"storageEngine"}, err, "expected error %v, got %v",
+				mongo.ErrMapForOrderedArgument{"storageEngine"}, err)
+		})
+		mt.Run("require existing collection", func(mt *mtest.T) {
+			model := mongo.IndexModel{Keys: bson.D{{"foo", 1}}}
+			opts := options.CreateIndexes().SetRequireExistingCollection(true)
+
+			mt.Run("existing collection", func(mt *mtest.T) {
+				_, err := mt.Coll.Indexes().CreateOne(context.Background(), model, opts)
+				assert.Nil(mt, err, "CreateOne error: %v", err)
+			})
+			mt.Run("missing collection", func(mt *mtest.T) {
+				coll := mt.CreateCollection(mtest.Collection{Name: "require_existing_missing"}, false)
+				_, err := coll.Indexes().CreateOne(context.Background(), model, opts)
+				assert.Equal(mt, mongo.ErrNamespaceNotFound, err, "expected error %v, got %v",
+					mongo.ErrNamespaceNotFound, err)
+
+				names, err := mt.DB.ListCollectionNames(context.Background(), bson.D{{"name", coll.Name()}})
+				assert.Nil(mt, err, "ListCollectionNames error: %v", err)
+				assert.Equal(mt, 0, len(names), "expected collection to not be created, got %v", names)
+			})
+		})
 		mt.Run("multi-key map", func(mt *mtest.T) {
 			iv := mt.Coll.Indexes()
 			_, err := iv.CreateMany(context.Background(), []mongo.IndexModel{
@@ -580,6 +1299,407 @@ func TestIndexView(t *testing.T) {
 			assert.True(mt, ok, "expected command %v to contain %q field", evt.Command, "maxTimeMS")
 			assert.Equal(mt, int64(100), maxTimeMS, "expected maxTimeMS value to be 100, got %d", maxTimeMS)
 		})
+		mt.RunOpts("build info", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+			buildUUID := primitive.Binary{Subtype: 4, Data: []byte("0123456789abcdef")}
+			ns := mt.DB.Name() + "." + mt.Coll.Name()
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch,
+				bson.D{{"spec", bson.D{{"v", 2}, {"key", bson.D{{"_id", 1}}}, {"name", "_id_"}}}},
+				bson.D{
+					{"spec", bson.D{{"v", 2}, {"key", bson.D{{"foo", 1}}}, {"name", "foo_1"}}},
+					{"indexBuildInfo", bson.D{{"buildUUID", buildUUID}}},
+				},
+			))
+
+			opts := options.ListIndexes().SetIncludeBuildInfo(true)
+			specs, err := mt.Coll.Indexes().ListSpecifications(context.Background(), opts)
+			assert.Nil(mt, err, "ListSpecifications error: %v", err)
+
+			evt := mt.GetStartedEvent()
+			include, ok := evt.Command.Lookup("includeIndexBuildInfo").BooleanOK()
+			assert.True(mt, ok && include, "expected command %v to set includeIndexBuildInfo", evt.Command)
+
+			assert.Equal(mt, 2, len(specs), "expected 2 specifications, got %d", len(specs))
+			assert.Equal(mt, "_id_", specs[0].Name, "expected name %q, got %q", "_id_", specs[0].Name)
+			assert.Nil(mt, specs[0].BuildUUID, "expected ready index to have no build UUID, got %v", specs[0].BuildUUID)
+			assert.Equal(mt, "foo_1", specs[1].Name, "expected name %q, got %q", "foo_1", specs[1].Name)
+			assert.Equal(mt, &buildUUID, specs[1].BuildUUID, "expected build UUID %v, got %v", buildUUID,
+				specs[1].BuildUUID)
+		})
+		mt.RunOpts("comment passed to listIndexes", mtest.NewOptions().MinServerVersion("4.4"), func(mt *mtest.T) {
+			opts := options.ListIndexes().SetComment("list specifications comment")
+			_, err := mt.Coll.Indexes().ListSpecifications(context.Background(), opts)
+			assert.Nil(mt, err, "ListSpecifications error: %v", err)
+
+			evt := mt.GetStartedEvent()
+			assert.Equal(mt, evt.CommandName, "listIndexes", "expected %q command to be sent, got %q", "listIndexes",
+				evt.CommandName)
+			comment, ok := evt.Command.Lookup("comment").StringValueOK()
+			assert.True(mt, ok, "expected command %v to contain %q field", evt.Command, "comment")
+			assert.Equal(mt, "list specifications comment", comment, "expected comment %q, got %q",
+				"list specifications comment", comment)
+		})
+	})
+	mt.Run("get by name", func(mt *mtest.T) {
+		iv := mt.Coll.Indexes()
+		_, err := iv.CreateOne(context.Background(), mongo.IndexModel{Keys: bson.D{{"foo", 1}}})
+		assert.Nil(mt, err, "CreateOne error: %v", err)
+
+		mt.Run("existing index", func(mt *mtest.T) {
+			spec, err := iv.GetByName(context.Background(), "foo_1")
+			assert.Nil(mt, err, "GetByName error: %v", err)
+			assert.Equal(mt, "foo_1", spec.Name, "expected name %q, got %q", "foo_1", spec.Name)
+		})
+		mt.Run("missing index", func(mt *mtest.T) {
+			_, err := iv.GetByName(context.Background(), "bar_1")
+			assert.Equal(mt, mongo.ErrIndexNotFound, err, "expected error %v, got %v", mongo.ErrIndexNotFound, err)
+		})
+		mt.Run("default _id index", func(mt *mtest.T) {
+			spec, err := iv.DefaultIDIndex(context.Background())
+			assert.Nil(mt, err, "DefaultIDIndex error: %v", err)
+			assert.Equal(mt, "_id_", spec.Name, "expected name %q, got %q", "_id_", spec.Name)
+
+			keys := bson.Raw(bsoncore.NewDocumentBuilder().AppendInt32("_id", 1).Build())
+			assert.Equal(mt, keys, spec.KeysDocument, "expected keys document %v, got %v", keys, spec.KeysDocument)
+		})
+	})
+	mt.Run("count", func(mt *mtest.T) {
+		iv := mt.Coll.Indexes()
+		_, err := iv.CreateMany(context.Background(), []mongo.IndexModel{
+			{Keys: bson.D{{"foo", 1}}},
+			{Keys: bson.D{{"bar", -1}}},
+		})
+		assert.Nil(mt, err, "CreateMany error: %v", err)
+
+		count, err := iv.Count(context.Background())
+		assert.Nil(mt, err, "Count error: %v", err)
+		specs, err := iv.ListSpecifications(context.Background())
+		assert.Nil(mt, err, "ListSpecifications error: %v", err)
+		assert.Equal(mt, len(specs), count, "expected count %d, got %d", len(specs), count)
+		assert.Equal(mt, 3, count, "expected 3 indexes, got %d", count)
+
+		missing := mt.CreateCollection(mtest.Collection{Name: "count_missing"}, false)
+		count, err = missing.Indexes().Count(context.Background())
+		assert.Nil(mt, err, "Count error: %v", err)
+		assert.Equal(mt, 0, count, "expected 0 indexes on a missing collection, got %d", count)
+	})
+	mt.Run("timeout", func(mt *mtest.T) {
+		iv := mt.Coll.Indexes()
+		createOpts := options.CreateIndexes().SetTimeout(10 * time.Second)
+		_, err := iv.CreateOne(context.Background(), mongo.IndexModel{Keys: bson.D{{"foo", 1}}}, createOpts)
+		assert.Nil(mt, err, "CreateOne error: %v", err)
+
+		evt := mt.GetStartedEvent()
+		assert.Equal(mt, "createIndexes", evt.CommandName, "expected %q command to be sent, got %q", "createIndexes",
+			evt.CommandName)
+		_, ok := evt.Command.Lookup("maxTimeMS").Int64OK()
+		assert.True(mt, ok, "expected command %v to contain %q field", evt.Command, "maxTimeMS")
+
+		mt.ClearEvents()
+		_, err = iv.ListSpecifications(context.Background())
+		assert.Nil(mt, err, "ListSpecifications error: %v", err)
+
+		evt = mt.GetStartedEvent()
+		assert.Equal(mt, "listIndexes", evt.CommandName, "expected %q command to be sent, got %q", "listIndexes",
+			evt.CommandName)
+		_, err = evt.Command.LookupErr("maxTimeMS")
+		assert.NotNil(mt, err, "expected command %v to not contain %q field", evt.Command, "maxTimeMS")
+
+		mt.ClearEvents()
+		_, err = iv.ListSpecifications(context.Background(), options.ListIndexes().SetTimeout(10*time.Second))
+		assert.Nil(mt, err, "ListSpecifications error: %v", err)
+		evt = mt.GetStartedEvent()
+		_, ok = evt.Command.Lookup("maxTimeMS").Int64OK()
+		assert.True(mt, ok, "expected command %v to contain %q field", evt.Command, "maxTimeMS")
+
+		mt.ClearEvents()
+		_, err = iv.DropOne(context.Background(), "foo_1", options.DropIndexes().SetTimeout(10*time.Second))
+		assert.Nil(mt, err, "DropOne error: %v", err)
+		evt = mt.GetStartedEvent()
+		_, ok = evt.Command.Lookup("maxTimeMS").Int64OK()
+		assert.True(mt, ok, "expected command %v to contain %q field", evt.Command, "maxTimeMS")
+	})
+	mt.Run("copy to", func(mt *mtest.T) {
+		_, err := mt.Coll.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+			{Keys: bson.D{{"foo", 1}}},
+			{Keys: bson.D{{"createdAt", 1}}, Options: options.Index().SetExpireAfterSeconds(3600)},
+			{
+				Keys:    bson.D{{"email", 1}},
+				Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.D{{"active", true}}),
+			},
+		})
+		assert.Nil(mt, err, "CreateMany error: %v", err)
+
+		dst := mt.CreateCollection(mtest.Collection{Name: mt.Coll.Name() + "_copy"}, true)
+		names, err := mt.Coll.Indexes().CopyTo(context.Background(), dst)
+		assert.Nil(mt, err, "CopyTo error: %v", err)
+		assert.Equal(mt, []string{"foo_1", "createdAt_1", "email_1"}, names, "expected created names %v, got %v",
+			[]string{"foo_1", "createdAt_1", "email_1"}, names)
+
+		src, err := mt.Coll.Indexes().ListSpecifications(context.Background())
+		assert.Nil(mt, err, "ListSpecifications error: %v", err)
+		copied, err := dst.Indexes().ListSpecifications(context.Background())
+		assert.Nil(mt, err, "ListSpecifications error: %v", err)
+		assert.Equal(mt, len(src), len(copied), "expected %d indexes, got %d", len(src), len(copied))
+		for i := range src {
+			assert.True(mt, src[i].Equal(*copied[i]), "expected index %v to equal %v", copied[i], src[i])
+		}
+
+		plan, err := dst.Indexes().Plan(context.Background(), []mongo.IndexModel{
+			{Keys: bson.D{{"foo", 1}}},
+			{Keys: bson.D{{"createdAt", 1}}, Options: options.Index().SetExpireAfterSeconds(3600)},
+			{
+				Keys:    bson.D{{"email", 1}},
+				Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.D{{"active", true}}),
+			},
+		})
+		assert.Nil(mt, err, "Plan error: %v", err)
+		assert.True(mt, plan.Empty(), "expected copied indexes to match the source options, got plan %+v", plan)
+	})
+	mt.Run("ttl", func(mt *mtest.T) {
+		iv := mt.Coll.Indexes()
+		_, err := iv.CreateOne(context.Background(), mongo.IndexModel{
+			Keys:    bson.D{{"createdAt", 1}},
+			Options: options.Index().SetExpireAfterSeconds(60),
+		})
+		assert.Nil(mt, err, "CreateOne error: %v", err)
+
+		mt.Run("no drift", func(mt *mtest.T) {
+			drift, current, err := iv.CompareTTL(context.Background(), "createdAt_1", 60)
+			assert.Nil(mt, err, "CompareTTL error: %v", err)
+			assert.False(mt, drift, "expected no drift")
+			assert.Equal(mt, pint32(60), current, "expected current TTL 60, got %v", current)
+		})
+		mt.Run("drift", func(mt *mtest.T) {
+			drift, current, err := iv.CompareTTL(context.Background(), "createdAt_1", 120)
+			assert.Nil(mt, err, "CompareTTL error: %v", err)
+			assert.True(mt, drift, "expected drift")
+			assert.Equal(mt, pint32(60), current, "expected current TTL 60, got %v", current)
+		})
+		mt.Run("collMod update", func(mt *mtest.T) {
+			err := iv.SetTTL(context.Background(), "createdAt_1", 120)
+			assert.Nil(mt, err, "SetTTL error: %v", err)
+
+			evt := mt.GetStartedEvent()
+			assert.Equal(mt, "collMod", evt.CommandName, "expected %q command to be sent, got %q", "collMod",
+				evt.CommandName)
+			name, ok := evt.Command.Lookup("index", "name").StringValueOK()
+			assert.True(mt, ok, "expected command %v to contain an index name", evt.Command)
+			assert.Equal(mt, "createdAt_1", name, "expected index name %q, got %q", "createdAt_1", name)
+
+			drift, current, err := iv.CompareTTL(context.Background(), "createdAt_1", 120)
+			assert.Nil(mt, err, "CompareTTL error: %v", err)
+			assert.False(mt, drift, "expected no drift after SetTTL, got current TTL %v", current)
+		})
+	})
+	mt.RunOpts("modify", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		pbool := func(b bool) *bool { return &b }
+
+		mt.Run("single field", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateSuccessResponse(
+				bson.E{"expireAfterSeconds_old", int64(60)},
+				bson.E{"expireAfterSeconds_new", int64(120)},
+			))
+
+			res, err := mt.Coll.Indexes().Modify(context.Background(), "createdAt_1", mongo.IndexModification{
+				ExpireAfterSeconds: pint32(120),
+			})
+			assert.Nil(mt, err, "Modify error: %v", err)
+
+			evt := mt.GetStartedEvent()
+			assert.Equal(mt, "collMod", evt.CommandName, "expected %q command to be sent, got %q", "collMod",
+				evt.CommandName)
+			expected := bson.D{{"name", "createdAt_1"}, {"expireAfterSeconds", int32(120)}}
+			wantIndex, err := bson.Marshal(expected)
+			assert.Nil(mt, err, "Marshal error: %v", err)
+			index := evt.Command.Lookup("index").Document()
+			assert.Equal(mt, bson.Raw(wantIndex), index, "expected index %v, got %v", bson.Raw(wantIndex), index)
+
+			assert.Equal(mt, pint32(60), res.ExpireAfterSecondsOld, "expected old TTL 60, got %v",
+				res.ExpireAfterSecondsOld)
+			assert.Equal(mt, pint32(120), res.ExpireAfterSecondsNew, "expected new TTL 120, got %v",
+				res.ExpireAfterSecondsNew)
+			assert.Nil(mt, res.HiddenOld, "expected hidden to be unchanged, got %v", res.HiddenOld)
+		})
+		mt.Run("multiple fields", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateSuccessResponse(
+				bson.E{"expireAfterSeconds_old", int64(60)},
+				bson.E{"expireAfterSeconds_new", int64(30)},
+				bson.E{"hidden_old", false},
+				bson.E{"hidden_new", true},
+				bson.E{"prepareUnique_old", false},
+				bson.E{"prepareUnique_new", true},
+			))
+
+			res, err := mt.Coll.Indexes().Modify(context.Background(), "createdAt_1", mongo.IndexModification{
+				ExpireAfterSeconds: pint32(30),
+				Hidden:             pbool(true),
+				PrepareUnique:      pbool(true),
+			})
+			assert.Nil(mt, err, "Modify error: %v", err)
+
+			expected := bson.D{
+				{"name", "createdAt_1"},
+				{"expireAfterSeconds", int32(30)},
+				{"hidden", true},
+				{"prepareUnique", true},
+			}
+			wantIndex, err := bson.Marshal(expected)
+			assert.Nil(mt, err, "Marshal error: %v", err)
+			index := mt.GetStartedEvent().Command.Lookup("index").Document()
+			assert.Equal(mt, bson.Raw(wantIndex), index, "expected index %v, got %v", bson.Raw(wantIndex), index)
+
+			want := &mongo.IndexModificationResult{
+				ExpireAfterSecondsOld: pint32(60),
+				ExpireAfterSecondsNew: pint32(30),
+				HiddenOld:             pbool(false),
+				HiddenNew:             pbool(true),
+				PrepareUniqueOld:      pbool(false),
+				PrepareUniqueNew:      pbool(true),
+			}
+			assert.Equal(mt, want, res, "expected result %+v, got %+v", want, res)
+		})
+		mt.Run("no fields", func(mt *mtest.T) {
+			_, err := mt.Coll.Indexes().Modify(context.Background(), "createdAt_1", mongo.IndexModification{})
+			assert.NotNil(mt, err, "expected Modify error, got nil")
+			assert.Nil(mt, mt.GetStartedEvent(), "expected no command to be sent")
+		})
+		mt.Run("convert to unique", func(mt *mtest.T) {
+			duplicates := bson.D{
+				{"ok", 0},
+				{"code", 359},
+				{"codeName", "CannotConvertIndexToUnique"},
+				{"errmsg", "Cannot convert the index to unique. Please resolve conflicting documents before running collMod again."},
+				{"violations", bson.A{bson.D{{"ids", bson.A{1, 2}}}}},
+			}
+
+			mt.Run("clean", func(mt *mtest.T) {
+				mt.AddMockResponses(mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse())
+
+				var called bool
+				err := mt.Coll.Indexes().ConvertToUniqueSafely(context.Background(), "email_1", func([]bson.Raw) error {
+					called = true
+					return nil
+				})
+				assert.Nil(mt, err, "ConvertToUniqueSafely error: %v", err)
+				assert.False(mt, called, "expected duplicates callback to not be called")
+
+				for _, field := range []string{"prepareUnique", "unique"} {
+					evt := mt.GetStartedEvent()
+					_, err := evt.Command.LookupErr("index", field)
+					assert.Nil(mt, err, "expected index.%s in command %v", field, evt.Command)
+				}
+			})
+			mt.Run("duplicates resolved", func(mt *mtest.T) {
+				mt.AddMockResponses(mtest.CreateSuccessResponse(), duplicates, mtest.CreateSuccessResponse())
+
+				var violations []bson.Raw
+				err := mt.Coll.Indexes().ConvertToUniqueSafely(context.Background(), "email_1", func(v []bson.Raw) error {
+					violations = v
+					return nil
+				})
+				assert.Nil(mt, err, "ConvertToUniqueSafely error: %v", err)
+				assert.Equal(mt, 1, len(violations), "expected 1 violation, got %v", violations)
+				ids, err := violations[0].LookupErr("ids")
+				assert.Nil(mt, err, "expected ids in violation %v", violations[0])
+				values, _ := ids.Array().Values()
+				assert.Equal(mt, 2, len(values), "expected 2 duplicate ids, got %v", ids)
+				assert.Equal(mt, 3, len(mt.GetAllStartedEvents()), "expected the unique conversion to be retried")
+			})
+			mt.Run("callback error", func(mt *mtest.T) {
+				mt.AddMockResponses(mtest.CreateSuccessResponse(), duplicates)
+
+				cbErr := errors.New("cannot resolve duplicates")
+				err := mt.Coll.Indexes().ConvertToUniqueSafely(context.Background(), "email_1", func([]bson.Raw) error {
+					return cbErr
+				})
+				assert.Equal(mt, cbErr, err, "expected error %v, got %v", cbErr, err)
+			})
+		})
+	})
+	mt.RunOpts("usage", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		since := time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+		ns := mt.DB.Name() + "." + mt.Coll.Name()
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch,
+			bson.D{
+				{"name", "_id_"},
+				{"key", bson.D{{"_id", 1}}},
+				{"host", "localhost:27017"},
+				{"accesses", bson.D{{"ops", int64(42)}, {"since", since}}},
+			},
+			bson.D{
+				{"name", "foo_1"},
+				{"key", bson.D{{"foo", 1}}},
+				{"host", "localhost:27017"},
+				{"accesses", bson.D{{"ops", int64(0)}, {"since", since}}},
+			},
+		))
+
+		usage, err := mt.Coll.Indexes().Usage(context.Background())
+		assert.Nil(mt, err, "Usage error: %v", err)
+
+		evt := mt.GetStartedEvent()
+		assert.Equal(mt, "aggregate", evt.CommandName, "expected %q command to be sent, got %q", "aggregate",
+			evt.CommandName)
+		stage, err := evt.Command.Lookup("pipeline").Array().IndexErr(0)
+		assert.Nil(mt, err, "expected pipeline to have a stage: %v", err)
+		_, err = stage.Value().Document().LookupErr("$indexStats")
+		assert.Nil(mt, err, "expected pipeline stage %v to be $indexStats", stage)
+
+		expected := []mongo.IndexUsage{
+			{
+				Name:         "_id_",
+				KeysDocument: bson.Raw(bsoncore.NewDocumentBuilder().AppendInt32("_id", 1).Build()),
+				Host:         "localhost:27017",
+				Ops:          42,
+				Since:        since,
+			},
+			{
+				Name:         "foo_1",
+				KeysDocument: bson.Raw(bsoncore.NewDocumentBuilder().AppendInt32("foo", 1).Build()),
+				Host:         "localhost:27017",
+				Ops:          0,
+				Since:        since,
+			},
+		}
+		assert.True(mt, cmp.Equal(usage, expected), "expected usage to match: %v", cmp.Diff(usage, expected))
+	})
+	mt.RunOpts("explain", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		queryPlanner := bson.D{{"namespace", mt.DB.Name() + "." + mt.Coll.Name()}}
+
+		testCases := []struct {
+			name    string
+			stage   string
+			explain func() (bson.Raw, error)
+		}{
+			{"usage", "$indexStats", func() (bson.Raw, error) {
+				return mt.Coll.Indexes().ExplainUsage(context.Background(), options.Aggregate().SetComment("explain"))
+			}},
+			{"search indexes", "$listSearchIndexes", func() (bson.Raw, error) {
+				opts := &options.ListSearchIndexesOptions{AggregateOpts: options.Aggregate().SetComment("explain")}
+				return mt.Coll.SearchIndexes().Explain(context.Background(), options.SearchIndexes().SetName("default"), opts)
+			}},
+		}
+		for _, tc := range testCases {
+			mt.Run(tc.name, func(mt *mtest.T) {
+				mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{"queryPlanner", queryPlanner}))
+
+				plan, err := tc.explain()
+				assert.Nil(mt, err, "explain error: %v", err)
+				_, err = plan.LookupErr("queryPlanner", "namespace")
+				assert.Nil(mt, err, "expected queryPlanner.namespace in plan %v", plan)
+
+				evt := mt.GetStartedEvent()
+				assert.Equal(mt, "explain", evt.CommandName, "expected %q command to be sent, got %q", "explain",
+					evt.CommandName)
+				stage, err := evt.Command.LookupErr("explain", "pipeline", "0")
+				assert.Nil(mt, err, "expected a pipeline in command %v", evt.Command)
+				_, err = stage.Document().LookupErr(tc.stage)
+				assert.Nil(mt, err, "expected stage %q in command %v", tc.stage, evt.Command)
+				comment, err := evt.Command.LookupErr("explain", "comment")
+				assert.Nil(mt, err, "expected a comment in command %v", evt.Command)
+				assert.Equal(mt, "explain", comment.StringValue(), "expected comment %q, got %v", "explain", comment)
+			})
+		}
 	})
 	mt.Run("drop one", func(mt *mtest.T) {
 		iv := mt.Coll.Indexes()
@@ -607,6 +1727,85 @@ func TestIndexView(t *testing.T) {
 		}
 		assert.Nil(mt, cursor.Err(), "cursor error: %v", cursor.Err())
 	})
+	mt.Run("server description", func(mt *mtest.T) {
+		iv := mt.Coll.Indexes()
+
+		res, err := iv.CreateManyWithResult(context.Background(), []mongo.IndexModel{{Keys: bson.D{{"foo", 1}}}})
+		assert.Nil(mt, err, "CreateManyWithResult error: %v", err)
+		addr := string(res.Server.Addr)
+		assert.NotEqual(mt, "", addr, "expected a server address")
+		assert.NotNil(mt, res.Server.WireVersion, "expected a wire version")
+		connID := mt.GetStartedEvent().ConnectionID
+		assert.True(mt, strings.HasPrefix(connID, addr+"["),
+			"expected createIndexes to be sent to %q, got connection %q", addr, connID)
+
+		mt.ClearEvents()
+		dropRes, err := iv.DropOneWithResult(context.Background(), "foo_1")
+		assert.Nil(mt, err, "DropOneWithResult error: %v", err)
+		assert.Equal(mt, int32(2), dropRes.NIndexesWas, "expected 2 indexes before the drop, got %d", dropRes.NIndexesWas)
+		addr = string(dropRes.Server.Addr)
+		connID = mt.GetStartedEvent().ConnectionID
+		assert.True(mt, strings.HasPrefix(connID, addr+"["),
+			"expected dropIndexes to be sent to %q, got connection %q", addr, connID)
+	})
+	mt.Run("rebuild", func(mt *mtest.T) {
+		mt.Run("success", func(mt *mtest.T) {
+			iv := mt.Coll.Indexes()
+			model := mongo.IndexModel{
+				Keys: bson.D{{"foo", 1}, {"bar", -1}},
+				Options: options.Index().
+					SetName("foo_bar").
+					SetUnique(true).
+					SetPartialFilterExpression(bson.D{{"foo", bson.D{{"$exists", true}}}}),
+			}
+			_, err := iv.CreateOne(context.Background(), model)
+			assert.Nil(mt, err, "CreateOne error: %v", err)
+			before, err := iv.GetByName(context.Background(), "foo_bar")
+			assert.Nil(mt, err, "GetByName error: %v", err)
+
+			mt.ClearEvents()
+			err = iv.Rebuild(context.Background(), "foo_bar")
+			assert.Nil(mt, err, "Rebuild error: %v", err)
+
+			var commands []string
+			for _, evt := range mt.GetAllStartedEvents() {
+				commands = append(commands, evt.CommandName)
+			}
+			expected := []string{"listIndexes", "dropIndexes", "createIndexes"}
+			assert.Equal(mt, expected, commands, "expected commands %v, got %v", expected, commands)
+
+			after, err := iv.GetByName(context.Background(), "foo_bar")
+			assert.Nil(mt, err, "GetByName error: %v", err)
+			assert.True(mt, before.Equal(*after), "expected rebuilt index %v to equal %v", after, before)
+		})
+		mt.Run("index not found", func(mt *mtest.T) {
+			err := mt.Coll.Indexes().Rebuild(context.Background(), "missing_1")
+			assert.Equal(mt, mongo.ErrIndexNotFound, err, "expected error %v, got %v", mongo.ErrIndexNotFound, err)
+		})
+		mt.RunOpts("recreate fails", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+			ns := mt.DB.Name() + "." + mt.Coll.Name()
+			mt.AddMockResponses(
+				mtest.CreateCursorResponse(0, ns, mtest.FirstBatch,
+					bson.D{{"v", 2}, {"key", bson.D{{"_id", 1}}}, {"name", "_id_"}},
+					bson.D{{"v", 2}, {"key", bson.D{{"foo", 1}}}, {"name", "foo_1"}, {"sparse", true}},
+				),
+				mtest.CreateSuccessResponse(bson.E{"nIndexesWas", int32(2)}),
+				mtest.CreateCommandErrorResponse(mtest.CommandError{
+					Code:    11000,
+					Name:    "DuplicateKey",
+					Message: "E11000 duplicate key error",
+				}),
+			)
+
+			err := mt.Coll.Indexes().Rebuild(context.Background(), "foo_1")
+			var rebuildErr mongo.IndexRebuildError
+			assert.True(mt, errors.As(err, &rebuildErr), "expected mongo.IndexRebuildError, got %v", err)
+			assert.Equal(mt, "foo_1", rebuildErr.Name, "expected name %q, got %q", "foo_1", rebuildErr.Name)
+			assert.True(mt, *rebuildErr.Model.Options.Sparse, "expected the model to keep the sparse option")
+			assert.True(mt, strings.Contains(err.Error(), "dropped but could not be recreated"),
+				"expected error to state that the index was dropped, got %v", err)
+		})
+	})
 	mt.Run("drop all", func(mt *mtest.T) {
 		iv := mt.Coll.Indexes()
 		names, err := iv.CreateMany(context.Background(), []mongo.IndexModel{
@@ -633,6 +1832,281 @@ func TestIndexView(t *testing.T) {
 		}
 		assert.Nil(mt, cursor.Err(), "cursor error: %v", cursor.Err())
 	})
+	mt.Run("drop many", func(mt *mtest.T) {
+		iv := mt.Coll.Indexes()
+		_, err := iv.CreateMany(context.Background(), []mongo.IndexModel{
+			{Keys: bson.D{{"foo", 1}}},
+			{Keys: bson.D{{"bar", 1}}},
+		})
+		assert.Nil(mt, err, "CreateMany error: %v", err)
+
+		res, err := iv.DropMany(context.Background(), []string{"foo_1", "missing_1", "bar_1"})
+		assert.Nil(mt, err, "DropMany error: %v", err)
+		expected := map[string]mongo.DropResult{
+			"foo_1":     {Dropped: true},
+			"missing_1": {Dropped: false},
+			"bar_1":     {Dropped: true},
+		}
+		assert.Equal(mt, expected, res, "expected results %v, got %v", expected, res)
+
+		res, err = iv.DropMany(context.Background(), []string{"foo_1", "bar_1"})
+		assert.Nil(mt, err, "DropMany error: %v", err)
+		expected = map[string]mongo.DropResult{
+			"foo_1": {Dropped: false},
+			"bar_1": {Dropped: false},
+		}
+		assert.Equal(mt, expected, res, "expected results %v, got %v", expected, res)
+
+		_, err = iv.DropMany(context.Background(), []string{"foo_1", "*"})
+		assert.Equal(mt, mongo.ErrMultipleIndexDrop, err, "expected error %v, got %v", mongo.ErrMultipleIndexDrop, err)
+	})
+	mt.Run("drop matching", func(mt *mtest.T) {
+		iv := mt.Coll.Indexes()
+		createIndexes := func(mt *mtest.T) {
+			mt.Helper()
+
+			_, err := iv.CreateMany(context.Background(), []mongo.IndexModel{
+				{Keys: bson.D{{"a", 1}}, Options: options.Index().SetName("tmp_a")},
+				{Keys: bson.D{{"b", 1}}, Options: options.Index().SetName("tmp_b")},
+				{Keys: bson.D{{"c", 1}}, Options: options.Index().SetName("keep_c")},
+			})
+			assert.Nil(mt, err, "CreateMany error: %v", err)
+		}
+		indexNames := func(mt *mtest.T) []string {
+			mt.Helper()
+
+			specs, err := iv.ListSpecifications(context.Background())
+			assert.Nil(mt, err, "ListSpecifications error: %v", err)
+			names := make([]string, 0, len(specs))
+			for _, spec := range specs {
+				names = append(names, spec.Name)
+			}
+			sort.Strings(names)
+			return names
+		}
+
+		mt.Run("some match", func(mt *mtest.T) {
+			createIndexes(mt)
+
+			dropped, err := iv.DropMatching(context.Background(), "tmp_*")
+			assert.Nil(mt, err, "DropMatching error: %v", err)
+			sort.Strings(dropped)
+			assert.Equal(mt, []string{"tmp_a", "tmp_b"}, dropped, "expected tmp_a and tmp_b to be dropped, got %v", dropped)
+			names := indexNames(mt)
+			assert.Equal(mt, []string{"_id_", "keep_c"}, names, "expected _id_ and keep_c to remain, got %v", names)
+		})
+		mt.Run("none match", func(mt *mtest.T) {
+			createIndexes(mt)
+
+			dropped, err := iv.DropMatching(context.Background(), "old_*")
+			assert.Nil(mt, err, "DropMatching error: %v", err)
+			assert.Equal(mt, 0, len(dropped), "expected no indexes to be dropped, got %v", dropped)
+			names := indexNames(mt)
+			assert.Equal(mt, 4, len(names), "expected 4 indexes to remain, got %v", names)
+		})
+		mt.Run("_id_ is protected", func(mt *mtest.T) {
+			createIndexes(mt)
+
+			dropped, err := iv.DropMatching(context.Background(), "_id*")
+			assert.Nil(mt, err, "DropMatching error: %v", err)
+			assert.Equal(mt, 0, len(dropped), "expected no indexes to be dropped, got %v", dropped)
+
+			mt.ClearEvents()
+			_, err = iv.DropMatching(context.Background(), "*")
+			assert.Equal(mt, mongo.ErrMultipleIndexDrop, err, "expected error %v, got %v", mongo.ErrMultipleIndexDrop, err)
+			assert.Nil(mt, mt.GetStartedEvent(), "expected no command to be sent")
+
+			dropped, err = iv.DropMatching(context.Background(), "*", options.DropIndexes().SetAllowMatchAll(true))
+			assert.Nil(mt, err, "DropMatching error: %v", err)
+			assert.Equal(mt, 3, len(dropped), "expected 3 indexes to be dropped, got %v", dropped)
+			names := indexNames(mt)
+			assert.Equal(mt, []string{"_id_"}, names, "expected only _id_ to remain, got %v", names)
+		})
+	})
+	mt.Run("plan", func(mt *mtest.T) {
+		iv := mt.Coll.Indexes()
+		_, err := iv.CreateMany(context.Background(), []mongo.IndexModel{
+			{Keys: bson.D{{"keep", 1}}},
+			{Keys: bson.D{{"change", 1}}},
+			{Keys: bson.D{{"remove", 1}}},
+		})
+		assert.Nil(mt, err, "CreateMany error: %v", err)
+
+		desired := []mongo.IndexModel{
+			{Keys: bson.D{{"keep", 1}}},
+			{Keys: bson.D{{"change", 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{"add", -1}}},
+		}
+		plan, err := iv.Plan(context.Background(), desired)
+		assert.Nil(mt, err, "Plan error: %v", err)
+
+		getNames := func(models []mongo.IndexModel) []string {
+			var names []string
+			for _, model := range models {
+				names = append(names, *model.Options.Name)
+			}
+			return names
+		}
+		assert.Equal(mt, []string{"add_-1"}, getNames(plan.Create), "expected indexes to create to match")
+		assert.Equal(mt, []string{"change_1"}, getNames(plan.Recreate), "expected indexes to recreate to match")
+		assert.Equal(mt, []string{"remove_1"}, plan.Drop, "expected indexes to drop to match")
+
+		// Plan must not modify the collection.
+		specs, err := iv.ListSpecifications(context.Background())
+		assert.Nil(mt, err, "ListSpecifications error: %v", err)
+		assert.Equal(mt, 4, len(specs), "expected 4 indexes after Plan, got %d", len(specs))
+
+		// Applying the plan brings the collection to the desired state.
+		for _, name := range plan.Drop {
+			_, err = iv.DropOne(context.Background(), name)
+			assert.Nil(mt, err, "DropOne error: %v", err)
+		}
+		for _, model := range plan.Recreate {
+			_, err = iv.DropOne(context.Background(), *model.Options.Name)
+			assert.Nil(mt, err, "DropOne error: %v", err)
+		}
+		_, err = iv.CreateMany(context.Background(), append(plan.Create, plan.Recreate...))
+		assert.Nil(mt, err, "CreateMany error: %v", err)
+
+		plan, err = iv.Plan(context.Background(), desired)
+		assert.Nil(mt, err, "Plan error: %v", err)
+		assert.True(mt, plan.Empty(), "expected empty plan after applying, got %+v", plan)
+	})
+	mt.Run("diff with", func(mt *mtest.T) {
+		iv := mt.Coll.Indexes()
+		_, err := iv.CreateMany(context.Background(), []mongo.IndexModel{
+			{Keys: bson.D{{"keep", 1}}},
+			{Keys: bson.D{{"change", 1}}},
+			{Keys: bson.D{{"remove", 1}}},
+		})
+		assert.Nil(mt, err, "CreateMany error: %v", err)
+
+		desired := []mongo.IndexModel{
+			{Keys: bson.D{{"keep", 1}}},
+			{Keys: bson.D{{"change", 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{"add", -1}}},
+		}
+		diff, err := iv.DiffWith(context.Background(), desired)
+		assert.Nil(mt, err, "DiffWith error: %v", err)
+
+		assert.Equal(mt, 1, len(diff.Add), "expected 1 index to add, got %d", len(diff.Add))
+		assert.Equal(mt, "add_-1", *diff.Add[0].Options.Name, "expected index to add to be add_-1, got %q",
+			*diff.Add[0].Options.Name)
+
+		assert.Equal(mt, 1, len(diff.Remove), "expected 1 index to remove, got %d", len(diff.Remove))
+		assert.Equal(mt, "remove_1", diff.Remove[0].Name, "expected index to remove to be remove_1, got %q",
+			diff.Remove[0].Name)
+
+		assert.Equal(mt, 1, len(diff.Change), "expected 1 index to change, got %d", len(diff.Change))
+		change := diff.Change[0]
+		assert.Equal(mt, "change_1", *change.Desired.Options.Name, "expected index to change to be change_1, got %q",
+			*change.Desired.Options.Name)
+		assert.Equal(mt, "change_1", change.Existing.Name, "expected existing index change_1, got %q",
+			change.Existing.Name)
+		assert.Nil(mt, change.Existing.Unique, "expected existing index to not be unique, got %v", change.Existing.Unique)
+
+		diff, err = iv.DiffWith(context.Background(), []mongo.IndexModel{
+			{Keys: bson.D{{"keep", 1}}},
+			{Keys: bson.D{{"change", 1}}},
+			{Keys: bson.D{{"remove", 1}}},
+		})
+		assert.Nil(mt, err, "DiffWith error: %v", err)
+		assert.True(mt, diff.Empty(), "expected empty diff, got %+v", diff)
+	})
+	mt.Run("reconcile", func(mt *mtest.T) {
+		iv := mt.Coll.Indexes()
+		_, err := iv.CreateMany(context.Background(), []mongo.IndexModel{
+			{Keys: bson.D{{"stale", 1}}},
+			{Keys: bson.D{{"email", 1}}},
+		})
+		assert.Nil(mt, err, "CreateMany error: %v", err)
+
+		desired := []mongo.IndexModel{
+			{Keys: bson.D{{"email", 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{"createdAt", -1}}},
+		}
+
+		mt.Run("progress", func(mt *mtest.T) {
+			var events []options.IndexProgressEvent
+			opts := options.ReconcileIndexes().SetProgressFn(func(evt options.IndexProgressEvent) {
+				events = append(events, evt)
+			})
+			_, err := iv.Reconcile(context.Background(), desired, opts)
+			assert.Nil(mt, err, "Reconcile error: %v", err)
+
+			expected := []options.IndexProgressEvent{
+				{Action: "drop", Name: "stale_1", Step: 1, Total: 4},
+				{Action: "drop", Name: "stale_1", Step: 1, Total: 4, Done: true},
+				{Action: "drop", Name: "email_1", Step: 2, Total: 4},
+				{Action: "drop", Name: "email_1", Step: 2, Total: 4, Done: true},
+				{Action: "create", Name: "createdAt_-1", Step: 3, Total: 4},
+				{Action: "create", Name: "createdAt_-1", Step: 3, Total: 4, Done: true},
+				{Action: "create", Name: "email_1", Step: 4, Total: 4},
+				{Action: "create", Name: "email_1", Step: 4, Total: 4, Done: true},
+			}
+			assert.Equal(mt, expected, events, "expected events %v, got %v", expected, events)
+
+			plan, err := iv.Plan(context.Background(), desired)
+			assert.Nil(mt, err, "Plan error: %v", err)
+			assert.True(mt, plan.Empty(), "expected empty plan after reconciling, got %+v", plan)
+		})
+		mt.Run("cancelled", func(mt *mtest.T) {
+			_, err := iv.CreateOne(context.Background(), mongo.IndexModel{Keys: bson.D{{"stale", 1}}})
+			assert.Nil(mt, err, "CreateOne error: %v", err)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var events int
+			opts := options.ReconcileIndexes().SetProgressFn(func(options.IndexProgressEvent) {
+				events++
+				cancel()
+			})
+			_, err = iv.Reconcile(ctx, desired, opts)
+			assert.True(mt, errors.Is(err, context.Canceled), "expected error %v, got %v", context.Canceled, err)
+			assert.Equal(mt, 1, events, "expected 1 progress event before cancellation, got %d", events)
+		})
+	})
+	mt.Run("operation name", func(mt *mtest.T) {
+		iv := mt.Coll.Indexes()
+		const opName = "ensure-indexes"
+
+		mt.ClearEvents()
+		_, err := iv.CreateOne(context.Background(), mongo.IndexModel{Keys: bson.D{{"foo", 1}}},
+			options.CreateIndexes().SetOperationName(opName))
+		assert.Nil(mt, err, "CreateOne error: %v", err)
+		started := mt.GetStartedEvent()
+		assert.Equal(mt, "createIndexes", started.CommandName, "expected command %q, got %q",
+			"createIndexes", started.CommandName)
+		assert.Equal(mt, opName, started.OperationName, "expected operation name %q on started event, got %q",
+			opName, started.OperationName)
+		_, ok := started.Command.Lookup("operationName").StringValueOK()
+		assert.False(mt, ok, "expected operation name to not be sent to the server")
+		succeeded := mt.GetSucceededEvent()
+		assert.Equal(mt, opName, succeeded.OperationName, "expected operation name %q on succeeded event, got %q",
+			opName, succeeded.OperationName)
+
+		mt.ClearEvents()
+		cursor, err := iv.List(context.Background(), options.ListIndexes().SetOperationName(opName))
+		assert.Nil(mt, err, "List error: %v", err)
+		_ = cursor.Close(context.Background())
+		started = mt.GetStartedEvent()
+		assert.Equal(mt, opName, started.OperationName, "expected operation name %q on listIndexes, got %q",
+			opName, started.OperationName)
+
+		mt.ClearEvents()
+		_, err = iv.DropOne(context.Background(), "foo_1", options.DropIndexes().SetOperationName(opName))
+		assert.Nil(mt, err, "DropOne error: %v", err)
+		started = mt.GetStartedEvent()
+		assert.Equal(mt, opName, started.OperationName, "expected operation name %q on dropIndexes, got %q",
+			opName, started.OperationName)
+
+		mt.ClearEvents()
+		_, err = iv.CreateOne(context.Background(), mongo.IndexModel{Keys: bson.D{{"bar", 1}}})
+		assert.Nil(mt, err, "CreateOne error: %v", err)
+		started = mt.GetStartedEvent()
+		assert.Equal(mt, "", started.OperationName, "expected no operation name, got %q", started.OperationName)
+	})
 	mt.RunOpts("clustered indexes", mtest.NewOptions().MinServerVersion("5.3"), func(mt *mtest.T) {
 		const name = "clustered"
 		clustered := mt.CreateCollection(mtest.Collection{
@@ -665,6 +2139,91 @@ func TestIndexView(t *testing.T) {
 			assert.True(mt, cmp.Equal(specs, expectedSpecs), "expected specifications to match: %v", cmp.Diff(specs, expectedSpecs))
 		})
 	})
+	mt.RunOpts("time-series collections", mtest.NewOptions().MinServerVersion("5.0"), func(mt *mtest.T) {
+		const name = "timeseries"
+		tsOpts := options.TimeSeries().SetTimeField("ts").SetMetaField("meta")
+		ts := mt.CreateCollection(mtest.Collection{
+			Name:       name,
+			CreateOpts: options.CreateCollection().SetTimeSeriesOptions(tsOpts),
+		}, true)
+		validate := options.CreateIndexes().SetValidateCollectionType(true)
+
+		mt.Run("allowed keys", func(mt *mtest.T) {
+			_, err := ts.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+				Keys: bson.D{{"meta.sensor", 1}, {"ts", -1}},
+			}, validate)
+			assert.Nil(mt, err, "CreateOne error: %v", err)
+		})
+		mt.RunOpts("disallowed keys", mtest.NewOptions().MaxServerVersion("5.3"), func(mt *mtest.T) {
+			mt.ClearEvents()
+			_, err := ts.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+				Keys: bson.D{{"temperature", 1}},
+			}, validate)
+			assert.NotNil(mt, err, "expected CreateOne error, got nil")
+
+			for evt := mt.GetStartedEvent(); evt != nil; evt = mt.GetStartedEvent() {
+				assert.NotEqual(mt, "createIndexes", evt.CommandName, "expected createIndexes not to be sent")
+			}
+		})
+		mt.RunOpts("measurement keys", mtest.NewOptions().MinServerVersion("6.0"), func(mt *mtest.T) {
+			_, err := ts.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+				Keys: bson.D{{"temperature", 1}},
+			}, validate)
+			assert.Nil(mt, err, "CreateOne error: %v", err)
+		})
+	})
+	mt.RunOpts("views", mtest.NewOptions().MinServerVersion("3.4"), func(mt *mtest.T) {
+		const viewName = "index_view_target"
+		err := mt.DB.CreateView(context.Background(), viewName, mt.Coll.Name(), mongo.Pipeline{})
+		assert.Nil(mt, err, "CreateView error: %v", err)
+		defer func() { _ = mt.DB.Collection(viewName).Drop(context.Background()) }()
+
+		view := mt.DB.Collection(viewName)
+		validate := options.CreateIndexes().SetValidateCollectionType(true)
+		model := mongo.IndexModel{Keys: bson.D{{"foo", 1}}}
+
+		for i := 0; i < 2; i++ {
+			mt.ClearEvents()
+			_, err = view.Indexes().CreateOne(context.Background(), model, validate)
+			assert.Equal(mt, mongo.ErrIndexOnView, err, "expected error %v, got %v", mongo.ErrIndexOnView, err)
+
+			var listCollections int
+			for evt := mt.GetStartedEvent(); evt != nil; evt = mt.GetStartedEvent() {
+				assert.NotEqual(mt, "createIndexes", evt.CommandName, "expected createIndexes not to be sent")
+				if evt.CommandName == "listCollections" {
+					listCollections++
+				}
+			}
+			// The collection type is looked up on every call because the namespace may be recreated.
+			assert.Equal(mt, 1, listCollections, "expected 1 listCollections command, got %d", listCollections)
+		}
+
+		// Without the lookup, the server's error is converted.
+		_, err = view.Indexes().CreateOne(context.Background(), model)
+		assert.True(mt, errors.Is(err, mongo.ErrIndexOnView), "expected error %v, got %v", mongo.ErrIndexOnView, err)
+	})
+	mt.RunOpts("collection lookup in a transaction", mtest.NewOptions().MinServerVersion("4.4").Topologies(mtest.ReplicaSet), func(mt *mtest.T) {
+		coll := mt.DB.Collection("index_view_txn_target")
+		defer func() { _ = coll.Drop(context.Background()) }()
+
+		sess, err := mt.Client.StartSession()
+		assert.Nil(mt, err, "StartSession error: %v", err)
+		defer sess.EndSession(context.Background())
+
+		_, err = sess.WithTransaction(context.Background(), func(sc mongo.SessionContext) (interface{}, error) {
+			opts := options.CreateIndexes().SetValidateCollectionType(true)
+			return coll.Indexes().CreateOne(sc, mongo.IndexModel{Keys: bson.D{{"foo", 1}}}, opts)
+		})
+		assert.Nil(mt, err, "WithTransaction error: %v", err)
+
+		for _, evt := range mt.GetAllStartedEvents() {
+			if evt.CommandName != "listCollections" {
+				continue
+			}
+			_, err := evt.Command.LookupErr("txnNumber")
+			assert.NotNil(mt, err, "expected listCollections to be sent outside the transaction")
+		}
+	})
 }
 
 func getIndexDoc(mt *mtest.T, iv mongo.IndexView, expectedKeyDoc bson.D) bson.D {
@@ -44,4 +44,27 @@ func TestCollation(t *testing.T) {
 			t.Fatalf("collation did not match expected. got %v; wanted %v", doc, expected)
 		}
 	})
+	t.Run("TestCollationSetters", func(t *testing.T) {
+		c := NewCollation("locale").SetCaseLevel(true).SetCaseFirst("first").SetStrength(1).
+			SetNumericOrdering(true).SetAlternate("alternate").SetMaxVariable("maxVariable").
+			SetNormalization(true).SetBackwards(true)
+		expected := &Collation{
+			Locale:          "locale",
+			CaseLevel:       true,
+			CaseFirst:       "first",
+			Strength:        1,
+			NumericOrdering: true,
+			Alternate:       "alternate",
+			MaxVariable:     "maxVariable",
+			Normalization:   true,
+			Backwards:       true,
+		}
+
+		if *c != *expected {
+			t.Fatalf("collation did not match expected. got %+v; wanted %+v", c, expected)
+		}
+		if c.SetLocale("fr").Locale != "fr" {
+			t.Fatalf("expected locale %q, got %q", "fr", c.Locale)
+		}
+	})
 }
@@ -7,9 +7,21 @@
 package options
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
 
+// CommitQuorumTag is the name of a custom write concern mode defined in the replica set configuration's
+// settings.getLastErrorModes. When used as the CommitQuorum value, all members with that tag must complete the build.
+type CommitQuorumTag string
+
 // CreateIndexesOptions represents options that can be used to configure IndexView.CreateOne and IndexView.CreateMany
 // operations.
 type CreateIndexesOptions struct {
@@ -17,16 +29,113 @@ type CreateIndexesOptions struct {
 	// successfully before the primary marks the indexes as ready. This should either be a string or int32 value. The
 	// semantics of the values are as follows:
 	//
-	// 1. String: specifies a tag. All members with that tag must complete the build.
+	// 1. string or CommitQuorumTag: specifies a tag. All members with that tag must complete the build.
 	// 2. int: the number of members that must complete the build.
 	// 3. "majority": A special value to indicate that more than half the nodes must complete the build.
 	// 4. "votingMembers": A special value to indicate that all voting data-bearing nodes must complete.
 	//
+	// Plain strings that differ from "majority" or "votingMembers" only in case, such as "Majority", are rejected with
+	// a client-side error to catch typos. Use CommitQuorumTag or SetCommitQuorumTag for a tag with such a name.
+	//
 	// This option is only available on MongoDB versions >= 4.4. A client-side error will be returned if the option
 	// is specified for MongoDB versions <= 4.2. The default value is nil, meaning that the server-side default will be
 	// used. See dochub.mongodb.org/core/index-commit-quorum for more information.
 	CommitQuorum interface{}
 
+	// If true, the indexes will be built in the foreground, blocking all other operations on the collection until the
+	// build completes. On servers that support it, each index is sent with "background" set to false. MongoDB versions
+	// >= 4.2 always use an optimized hybrid build process, so a client-side error will be returned if this option is
+	// set for those versions. The default value is nil, meaning that the server-side default will be used.
+	Foreground *bool
+
+	// If true, the target collection will be looked up with a listCollections command before the createIndexes
+	// command is sent, and a client-side error will be returned for indexes that the collection cannot support. For
+	// example, on MongoDB versions < 6.0, indexes on a time-series collection may only reference its timeField and
+	// metaField, and mongo.ErrIndexOnView is returned if the namespace is a view. The lookup is done on every call. The
+	// default value is nil, meaning that no lookup is done and validation is left to the server.
+	ValidateCollectionType *bool
+
+	// If true, the target collection will be looked up with a listCollections command before the createIndexes
+	// command is sent, and mongo.ErrNamespaceNotFound will be returned if it does not exist instead of the server
+	// implicitly creating it. The default value is nil, meaning that the collection is created if necessary.
+	RequireExistingCollection *bool
+
+	// If true, the indexes will be listed after they are created and an error will be returned if an index whose
+	// IndexOptions.Hidden option is true is not reported as hidden by the server. This detects servers that silently
+	// ignore the hidden option. The indexes are still created if the verification fails. The default value is nil,
+	// meaning that no verification is done.
+	VerifyHidden *bool
+
+	// If true, the indexes will be listed after they are created and the names returned by IndexView.CreateOne and
+	// IndexView.CreateMany will be the names reported by the server rather than the names generated by the driver.
+	// An index is matched to the server's specification by name first and then by its keys. If an index cannot be
+	// matched to exactly one specification, the generated name is returned. The default value is nil, meaning that
+	// the generated names are returned without listing the indexes.
+	VerifyNames *bool
+
+	// If true, the value of each index key field is validated before the createIndexes command is sent. Numeric
+	// values must be 1 or -1, and string values must be one of the index types "text", "hashed", "2d", "2dsphere",
+	// or "geoHaystack". Other values, such as 0 or a misspelled index type, return a client-side error instead of the
+	// server's error. The default value is nil, meaning that only the value types are checked and other values are
+	// left to the server.
+	ValidateKeyValues *bool
+
+	// The minimum server version required to create the indexes, such as "4.2" for wildcard indexes. If set, the
+	// server's version is looked up with a buildInfo command before the createIndexes command is sent, and a
+	// mongo.ServerVersionError is returned without creating any indexes if the server is older. The default value is
+	// nil, meaning that no version check is done.
+	MinServerVersion *string
+
+	// The write concern to use for this operation instead of the collection's write concern. As with the collection's
+	// write concern, it is not sent if the operation is part of a transaction. The default value is nil, which means
+	// that the collection's write concern is used.
+	WriteConcern *writeconcern.WriteConcern
+
+	// The maximum number of indexes to send in each createIndexes command. If there are more models than this, the
+	// indexes are created with several commands that are sent one at a time. If a command fails after earlier ones
+	// succeeded, a mongo.PartialIndexCreationError with the names of the indexes that were created is returned. This
+	// must be greater than 0. The default value is nil, which means that all indexes are sent in a single command.
+	BatchSize *int32
+
+	// The order in which the indexes are sent to the server, which is also the order in which they are split into
+	// batches if BatchSize is set. Building cheaper indexes first can reduce replication lag while the more expensive
+	// builds run. The default value is IndexBuildOrderAsGiven.
+	BuildOrder IndexBuildOrder
+
+	// Specifies the storage engine to use for each index that does not set IndexOptions.StorageEngine. The value must
+	// be a document in the form {<storage engine name>: <options>}, and map types with more than one key are not
+	// valid. The default value is nil, which means that the default storage engine will be used.
+	DefaultStorageEngine interface{}
+
+	// A structured marker that is attached to the createIndexes command as its comment, so tools that observe DDL
+	// operations, such as through change streams or the profiler, can identify index builds consistently. The value
+	// must be a non-empty document whose field names do not start with "$", and map types with more than one key are
+	// not valid. This option is only valid for MongoDB versions >= 4.4. The default value is nil, which means that no
+	// comment will be attached.
+	AuditComment interface{}
+
+	// Advanced: the name of the shard to build the indexes on. If set, the createIndexes command is only sent to a
+	// member of the replica set with this name, so the client must be connected to the shard's members directly rather
+	// than through mongos. This is intended for diagnostics and maintenance, such as building an index on one shard
+	// before the others. Normal usage should leave this unset and let mongos coordinate the build across all shards.
+	// If the client is connected through mongos, mongo.ErrShardRequiresDirectConnection is returned. The default value
+	// is nil, meaning that servers are selected as usual.
+	Shard *string
+
+	// An application-provided name for the operation that is included in the OperationName field of the command
+	// monitoring events it publishes. Unlike Comment, the name is not sent to the server. The default value is nil,
+	// which means that the events have an empty OperationName.
+	OperationName *string
+
+	// The amount of time that this operation can execute before returning an error. If set, this overrides the Timeout
+	// configured on the Client for this operation only. The default value is nil, meaning that the Client's Timeout is
+	// used. If the createIndexes command is retried because a background operation is in progress, the timeout covers
+	// all of the attempts for a batch together.
+	//
+	// NOTE: Timeout represents unstable, provisional API. The behavior of the driver when a Timeout is specified is
+	// subject to change.
+	Timeout *time.Duration
+
 	// The maximum amount of time that the query can run on the server. The default value is nil, meaning that there
 	// is no time limit for query execution.
 	//
@@ -34,8 +143,31 @@ type CreateIndexesOptions struct {
 	// in its place to control the amount of time that a single operation can run before returning an error. MaxTime
 	// is ignored if Timeout is set on the client.
 	MaxTime *time.Duration
+
+	// BSONOptions configures optional BSON marshaling behavior for the index models in this operation. The default
+	// value is nil, which means that the BSONOptions of the Collection will be used.
+	BSONOptions *BSONOptions
+
+	// Registry is the BSON registry used to marshal the index models in this operation, such as a partial filter
+	// expression containing a custom type. The default value is nil, which means that the registry of the Collection
+	// will be used.
+	Registry *bsoncodec.Registry
 }
 
+// IndexBuildOrder specifies the order in which IndexView.CreateMany sends indexes to the server.
+type IndexBuildOrder string
+
+// These constants specify valid values for the CreateIndexesOptions.BuildOrder field.
+const (
+	// IndexBuildOrderAsGiven sends the indexes in the same order as the index models.
+	IndexBuildOrderAsGiven IndexBuildOrder = ""
+
+	// IndexBuildOrderByCost sends the indexes in order of increasing estimated build cost. The estimate is based on
+	// the number and type of the index keys and whether the index is unique. Indexes with the same estimated cost are
+	// kept in the same order as the index models.
+	IndexBuildOrderByCost IndexBuildOrder = "byCost"
+)
+
 // CreateIndexes creates a new CreateIndexesOptions instance.
 func CreateIndexes() *CreateIndexesOptions {
 	return &CreateIndexesOptions{}
@@ -51,6 +183,111 @@ func (c *CreateIndexesOptions) SetMaxTime(d time.Duration) *CreateIndexesOptions
 	return c
 }
 
+// SetBSONOptions sets the value for the BSONOptions field.
+func (c *CreateIndexesOptions) SetBSONOptions(opts *BSONOptions) *CreateIndexesOptions {
+	c.BSONOptions = opts
+	return c
+}
+
+// SetRegistry sets the value for the Registry field.
+func (c *CreateIndexesOptions) SetRegistry(r *bsoncodec.Registry) *CreateIndexesOptions {
+	c.Registry = r
+	return c
+}
+
+// SetForeground sets the value for the Foreground field.
+func (c *CreateIndexesOptions) SetForeground(foreground bool) *CreateIndexesOptions {
+	c.Foreground = &foreground
+	return c
+}
+
+// SetValidateCollectionType sets the value for the ValidateCollectionType field.
+func (c *CreateIndexesOptions) SetValidateCollectionType(validate bool) *CreateIndexesOptions {
+	c.ValidateCollectionType = &validate
+	return c
+}
+
+// SetRequireExistingCollection sets the value for the RequireExistingCollection field.
+func (c *CreateIndexesOptions) SetRequireExistingCollection(require bool) *CreateIndexesOptions {
+	c.RequireExistingCollection = &require
+	return c
+}
+
+// SetBuildOrder sets the value for the BuildOrder field.
+func (c *CreateIndexesOptions) SetBuildOrder(order IndexBuildOrder) *CreateIndexesOptions {
+	c.BuildOrder = order
+	return c
+}
+
+// SetBatchSize sets the value for the BatchSize field.
+func (c *CreateIndexesOptions) SetBatchSize(size int32) *CreateIndexesOptions {
+	c.BatchSize = &size
+	return c
+}
+
+// SetVerifyHidden sets the value for the VerifyHidden field.
+func (c *CreateIndexesOptions) SetVerifyHidden(verify bool) *CreateIndexesOptions {
+	c.VerifyHidden = &verify
+	return c
+}
+
+// SetVerifyNames sets the value for the VerifyNames field.
+func (c *CreateIndexesOptions) SetVerifyNames(verify bool) *CreateIndexesOptions {
+	c.VerifyNames = &verify
+	return c
+}
+
+// SetValidateKeyValues sets the value for the ValidateKeyValues field.
+func (c *CreateIndexesOptions) SetValidateKeyValues(validate bool) *CreateIndexesOptions {
+	c.ValidateKeyValues = &validate
+	return c
+}
+
+// SetMinServerVersion sets the value for the MinServerVersion field.
+func (c *CreateIndexesOptions) SetMinServerVersion(version string) *CreateIndexesOptions {
+	c.MinServerVersion = &version
+	return c
+}
+
+// SetWriteConcern sets the value for the WriteConcern field.
+func (c *CreateIndexesOptions) SetWriteConcern(wc *writeconcern.WriteConcern) *CreateIndexesOptions {
+	c.WriteConcern = wc
+	return c
+}
+
+// SetDefaultStorageEngine sets the value for the DefaultStorageEngine field.
+func (c *CreateIndexesOptions) SetDefaultStorageEngine(engine interface{}) *CreateIndexesOptions {
+	c.DefaultStorageEngine = engine
+	return c
+}
+
+// SetAuditComment sets the value for the AuditComment field.
+func (c *CreateIndexesOptions) SetAuditComment(comment interface{}) *CreateIndexesOptions {
+	c.AuditComment = comment
+	return c
+}
+
+// SetShard sets the value for the Shard field.
+func (c *CreateIndexesOptions) SetShard(shard string) *CreateIndexesOptions {
+	c.Shard = &shard
+	return c
+}
+
+// SetOperationName sets the value for the OperationName field.
+func (c *CreateIndexesOptions) SetOperationName(name string) *CreateIndexesOptions {
+	c.OperationName = &name
+	return c
+}
+
+// SetTimeout sets the value for the Timeout field.
+//
+// NOTE: SetTimeout represents unstable, provisional API. The behavior of the driver when a Timeout is specified is
+// subject to change.
+func (c *CreateIndexesOptions) SetTimeout(timeout time.Duration) *CreateIndexesOptions {
+	c.Timeout = &timeout
+	return c
+}
+
 // SetCommitQuorumInt sets the value for the CommitQuorum field as an int32.
 func (c *CreateIndexesOptions) SetCommitQuorumInt(quorum int32) *CreateIndexesOptions {
 	c.CommitQuorum = quorum
@@ -63,6 +300,12 @@ func (c *CreateIndexesOptions) SetCommitQuorumString(quorum string) *CreateIndex
 	return c
 }
 
+// SetCommitQuorumTag sets the value for the CommitQuorum field to the name of a tag.
+func (c *CreateIndexesOptions) SetCommitQuorumTag(tag string) *CreateIndexesOptions {
+	c.CommitQuorum = CommitQuorumTag(tag)
+	return c
+}
+
 // SetCommitQuorumMajority sets the value for the CommitQuorum to special "majority" value.
 func (c *CreateIndexesOptions) SetCommitQuorumMajority() *CreateIndexesOptions {
 	c.CommitQuorum = "majority"
@@ -92,6 +335,94 @@ func MergeCreateIndexesOptions(opts ...*CreateIndexesOptions) *CreateIndexesOpti
 		if opt.CommitQuorum != nil {
 			c.CommitQuorum = opt.CommitQuorum
 		}
+		if opt.Foreground != nil {
+			c.Foreground = opt.Foreground
+		}
+		if opt.ValidateCollectionType != nil {
+			c.ValidateCollectionType = opt.ValidateCollectionType
+		}
+		if opt.RequireExistingCollection != nil {
+			c.RequireExistingCollection = opt.RequireExistingCollection
+		}
+		if opt.VerifyHidden != nil {
+			c.VerifyHidden = opt.VerifyHidden
+		}
+		if opt.VerifyNames != nil {
+			c.VerifyNames = opt.VerifyNames
+		}
+		if opt.ValidateKeyValues != nil {
+			c.ValidateKeyValues = opt.ValidateKeyValues
+		}
+		if opt.MinServerVersion != nil {
+			c.MinServerVersion = opt.MinServerVersion
+		}
+		if opt.WriteConcern != nil {
+			c.WriteConcern = opt.WriteConcern
+		}
+		if opt.BatchSize != nil {
+			c.BatchSize = opt.BatchSize
+		}
+		if opt.BuildOrder != "" {
+			c.BuildOrder = opt.BuildOrder
+		}
+		if opt.BSONOptions != nil {
+			c.BSONOptions = opt.BSONOptions
+		}
+		if opt.Registry != nil {
+			c.Registry = opt.Registry
+		}
+		if opt.DefaultStorageEngine != nil {
+			c.DefaultStorageEngine = opt.DefaultStorageEngine
+		}
+		if opt.Shard != nil {
+			c.Shard = opt.Shard
+		}
+		if opt.AuditComment != nil {
+			c.AuditComment = opt.AuditComment
+		}
+		if opt.OperationName != nil {
+			c.OperationName = opt.OperationName
+		}
+		if opt.Timeout != nil {
+			c.Timeout = opt.Timeout
+		}
+	}
+
+	return c
+}
+
+// CopyIndexesOptions represents options that can be used to configure an IndexView.CopyTo operation.
+type CopyIndexesOptions struct {
+	// If true, the "_id_" index is included in the indexes created on the destination collection. The default value is
+	// false.
+	IncludeIDIndex *bool
+}
+
+// CopyIndexes creates a new CopyIndexesOptions instance.
+func CopyIndexes() *CopyIndexesOptions {
+	return &CopyIndexesOptions{}
+}
+
+// SetIncludeIDIndex sets the value for the IncludeIDIndex field.
+func (c *CopyIndexesOptions) SetIncludeIDIndex(include bool) *CopyIndexesOptions {
+	c.IncludeIDIndex = &include
+	return c
+}
+
+// MergeCopyIndexesOptions combines the given CopyIndexesOptions into a single CopyIndexesOptions in a last one wins
+// fashion.
+//
+// Deprecated: Merging options structs will not be supported in Go Driver 2.0. Users should create a
+// single options struct instead.
+func MergeCopyIndexesOptions(opts ...*CopyIndexesOptions) *CopyIndexesOptions {
+	c := CopyIndexes()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.IncludeIDIndex != nil {
+			c.IncludeIDIndex = opt.IncludeIDIndex
+		}
 	}
 
 	return c
@@ -100,6 +431,23 @@ func MergeCreateIndexesOptions(opts ...*CreateIndexesOptions) *CreateIndexesOpti
 // DropIndexesOptions represents options that can be used to configure IndexView.DropOne and IndexView.DropAll
 // operations.
 type DropIndexesOptions struct {
+	// If true, IndexView.DropMatching is allowed to use a pattern that matches every index name, such as "*". Other
+	// operations ignore this option. The default value is nil, meaning that such patterns are rejected.
+	AllowMatchAll *bool
+
+	// An application-provided name for the operation that is included in the OperationName field of the command
+	// monitoring events it publishes. Unlike Comment, the name is not sent to the server. The default value is nil,
+	// which means that the events have an empty OperationName.
+	OperationName *string
+
+	// The amount of time that this operation can execute before returning an error. If set, this overrides the Timeout
+	// configured on the Client for this operation only. The default value is nil, meaning that the Client's Timeout is
+	// used.
+	//
+	// NOTE: Timeout represents unstable, provisional API. The behavior of the driver when a Timeout is specified is
+	// subject to change.
+	Timeout *time.Duration
+
 	// The maximum amount of time that the query can run on the server. The default value is nil, meaning that there
 	// is no time limit for query execution.
 	//
@@ -124,6 +472,27 @@ func (d *DropIndexesOptions) SetMaxTime(duration time.Duration) *DropIndexesOpti
 	return d
 }
 
+// SetAllowMatchAll sets the value for the AllowMatchAll field.
+func (d *DropIndexesOptions) SetAllowMatchAll(allow bool) *DropIndexesOptions {
+	d.AllowMatchAll = &allow
+	return d
+}
+
+// SetOperationName sets the value for the OperationName field.
+func (d *DropIndexesOptions) SetOperationName(name string) *DropIndexesOptions {
+	d.OperationName = &name
+	return d
+}
+
+// SetTimeout sets the value for the Timeout field.
+//
+// NOTE: SetTimeout represents unstable, provisional API. The behavior of the driver when a Timeout is specified is
+// subject to change.
+func (d *DropIndexesOptions) SetTimeout(timeout time.Duration) *DropIndexesOptions {
+	d.Timeout = &timeout
+	return d
+}
+
 // MergeDropIndexesOptions combines the given DropIndexesOptions into a single DropIndexesOptions in a last-one-wins
 // fashion.
 //
@@ -135,9 +504,18 @@ func MergeDropIndexesOptions(opts ...*DropIndexesOptions) *DropIndexesOptions {
 		if opt == nil {
 			continue
 		}
+		if opt.AllowMatchAll != nil {
+			c.AllowMatchAll = opt.AllowMatchAll
+		}
 		if opt.MaxTime != nil {
 			c.MaxTime = opt.MaxTime
 		}
+		if opt.OperationName != nil {
+			c.OperationName = opt.OperationName
+		}
+		if opt.Timeout != nil {
+			c.Timeout = opt.Timeout
+		}
 	}
 
 	return c
@@ -148,6 +526,30 @@ type ListIndexesOptions struct {
 	// The maximum number of documents to be included in each batch returned by the server.
 	BatchSize *int32
 
+	// A string or document that will be included in server logs, profiling logs, and currentOp queries to help trace
+	// the operation. The default value is nil, which means that no comment will be included in the logs.
+	Comment interface{}
+
+	// If true, the server will include indexes that are still being built and report their build information. Each
+	// returned document has the index specification in a "spec" field and, for an index that is being built, an
+	// "indexBuildInfo" field with the build UUID. IndexView.ListSpecifications decodes these documents and sets the
+	// IndexSpecification.BuildUUID field for indexes that are being built. This option is only valid for MongoDB
+	// versions >= 6.3. The default value is nil, which means that only the specifications of ready indexes are returned.
+	IncludeBuildInfo *bool
+
+	// An application-provided name for the operation that is included in the OperationName field of the command
+	// monitoring events it publishes. Unlike Comment, the name is not sent to the server. The default value is nil,
+	// which means that the events have an empty OperationName.
+	OperationName *string
+
+	// The amount of time that this operation can execute before returning an error. If set, this overrides the Timeout
+	// configured on the Client for this operation only. The default value is nil, meaning that the Client's Timeout is
+	// used.
+	//
+	// NOTE: Timeout represents unstable, provisional API. The behavior of the driver when a Timeout is specified is
+	// subject to change.
+	Timeout *time.Duration
+
 	// The maximum amount of time that the query can run on the server. The default value is nil, meaning that there
 	// is no time limit for query execution.
 	//
@@ -155,6 +557,14 @@ type ListIndexesOptions struct {
 	// in its place to control the amount of time that a single operation can run before returning an error. MaxTime
 	// is ignored if Timeout is set on the client.
 	MaxTime *time.Duration
+
+	// BSONOptions configures optional BSON marshaling and unmarshaling behavior for this operation and the returned
+	// cursor. The default value is nil, which means that the BSONOptions of the Collection will be used.
+	BSONOptions *BSONOptions
+
+	// Registry is the BSON registry used to marshal values for this operation and to decode documents from the
+	// returned cursor. The default value is nil, which means that the registry of the Collection will be used.
+	Registry *bsoncodec.Registry
 }
 
 // ListIndexes creates a new ListIndexesOptions instance.
@@ -168,6 +578,45 @@ func (l *ListIndexesOptions) SetBatchSize(i int32) *ListIndexesOptions {
 	return l
 }
 
+// SetIncludeBuildInfo sets the value for the IncludeBuildInfo field.
+func (l *ListIndexesOptions) SetIncludeBuildInfo(include bool) *ListIndexesOptions {
+	l.IncludeBuildInfo = &include
+	return l
+}
+
+// SetBSONOptions sets the value for the BSONOptions field.
+func (l *ListIndexesOptions) SetBSONOptions(opts *BSONOptions) *ListIndexesOptions {
+	l.BSONOptions = opts
+	return l
+}
+
+// SetRegistry sets the value for the Registry field.
+func (l *ListIndexesOptions) SetRegistry(r *bsoncodec.Registry) *ListIndexesOptions {
+	l.Registry = r
+	return l
+}
+
+// SetComment sets the value for the Comment field.
+func (l *ListIndexesOptions) SetComment(comment interface{}) *ListIndexesOptions {
+	l.Comment = comment
+	return l
+}
+
+// SetOperationName sets the value for the OperationName field.
+func (l *ListIndexesOptions) SetOperationName(name string) *ListIndexesOptions {
+	l.OperationName = &name
+	return l
+}
+
+// SetTimeout sets the value for the Timeout field.
+//
+// NOTE: SetTimeout represents unstable, provisional API. The behavior of the driver when a Timeout is specified is
+// subject to change.
+func (l *ListIndexesOptions) SetTimeout(timeout time.Duration) *ListIndexesOptions {
+	l.Timeout = &timeout
+	return l
+}
+
 // SetMaxTime sets the value for the MaxTime field.
 //
 // NOTE(benjirewis): MaxTime will be deprecated in a future release. The more general Timeout
@@ -192,6 +641,24 @@ func MergeListIndexesOptions(opts ...*ListIndexesOptions) *ListIndexesOptions {
 		if opt.BatchSize != nil {
 			c.BatchSize = opt.BatchSize
 		}
+		if opt.Comment != nil {
+			c.Comment = opt.Comment
+		}
+		if opt.IncludeBuildInfo != nil {
+			c.IncludeBuildInfo = opt.IncludeBuildInfo
+		}
+		if opt.OperationName != nil {
+			c.OperationName = opt.OperationName
+		}
+		if opt.Timeout != nil {
+			c.Timeout = opt.Timeout
+		}
+		if opt.BSONOptions != nil {
+			c.BSONOptions = opt.BSONOptions
+		}
+		if opt.Registry != nil {
+			c.Registry = opt.Registry
+		}
 		if opt.MaxTime != nil {
 			c.MaxTime = opt.MaxTime
 		}
@@ -200,6 +667,150 @@ func MergeListIndexesOptions(opts ...*ListIndexesOptions) *ListIndexesOptions {
 	return c
 }
 
+// AllIndexesOptions represents options that can be used to configure a Database.AllIndexes operation.
+type AllIndexesOptions struct {
+	// If true, the indexes of system collections, whose names start with "system.", are included. The default value
+	// is nil, meaning that system collections are skipped.
+	IncludeSystemCollections *bool
+}
+
+// AllIndexes creates a new AllIndexesOptions instance.
+func AllIndexes() *AllIndexesOptions {
+	return &AllIndexesOptions{}
+}
+
+// SetIncludeSystemCollections sets the value for the IncludeSystemCollections field.
+func (a *AllIndexesOptions) SetIncludeSystemCollections(include bool) *AllIndexesOptions {
+	a.IncludeSystemCollections = &include
+	return a
+}
+
+// MergeAllIndexesOptions combines the given AllIndexesOptions instances into a single *AllIndexesOptions in a
+// last-one-wins fashion.
+//
+// Deprecated: Merging options structs will not be supported in Go Driver 2.0. Users should create a
+// single options struct instead.
+func MergeAllIndexesOptions(opts ...*AllIndexesOptions) *AllIndexesOptions {
+	a := AllIndexes()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.IncludeSystemCollections != nil {
+			a.IncludeSystemCollections = opt.IncludeSystemCollections
+		}
+	}
+
+	return a
+}
+
+// PlanIndexesOptions represents options that can be used to configure an IndexView.Plan operation.
+type PlanIndexesOptions struct {
+	// If true, indexes that exist on the collection but are not in the desired set will not be reported for removal.
+	// The default value is false.
+	KeepUnlisted *bool
+}
+
+// PlanIndexes creates a new PlanIndexesOptions instance.
+func PlanIndexes() *PlanIndexesOptions {
+	return &PlanIndexesOptions{}
+}
+
+// SetKeepUnlisted sets the value for the KeepUnlisted field.
+func (p *PlanIndexesOptions) SetKeepUnlisted(keep bool) *PlanIndexesOptions {
+	p.KeepUnlisted = &keep
+	return p
+}
+
+// MergePlanIndexesOptions combines the given PlanIndexesOptions instances into a single *PlanIndexesOptions in a
+// last-one-wins fashion.
+//
+// Deprecated: Merging options structs will not be supported in Go Driver 2.0. Users should create a
+// single options struct instead.
+func MergePlanIndexesOptions(opts ...*PlanIndexesOptions) *PlanIndexesOptions {
+	p := PlanIndexes()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.KeepUnlisted != nil {
+			p.KeepUnlisted = opt.KeepUnlisted
+		}
+	}
+
+	return p
+}
+
+// IndexProgressEvent describes a step of an IndexView.Reconcile operation. It is passed to the
+// ReconcileIndexesOptions.ProgressFn callback before and after each index is dropped or created.
+type IndexProgressEvent struct {
+	// The step being performed, either "drop" or "create".
+	Action string
+
+	// The name of the index being dropped or created.
+	Name string
+
+	// The 1-based position of this step and the total number of steps.
+	Step, Total int
+
+	// False if the event is reported before the step is performed and true if it is reported after the step finished.
+	Done bool
+
+	// The error returned by the step. This is only set if Done is true.
+	Err error
+}
+
+// ReconcileIndexesOptions represents options that can be used to configure an IndexView.Reconcile operation.
+type ReconcileIndexesOptions struct {
+	// If true, indexes that exist on the collection but are not in the desired set will not be dropped. The default
+	// value is false.
+	KeepUnlisted *bool
+
+	// A function that is called before and after each index is dropped or created, which can be used to report the
+	// progress of the operation. It is not called after the context is cancelled. The default value is nil, which means
+	// that progress is not reported.
+	ProgressFn func(IndexProgressEvent)
+}
+
+// ReconcileIndexes creates a new ReconcileIndexesOptions instance.
+func ReconcileIndexes() *ReconcileIndexesOptions {
+	return &ReconcileIndexesOptions{}
+}
+
+// SetKeepUnlisted sets the value for the KeepUnlisted field.
+func (r *ReconcileIndexesOptions) SetKeepUnlisted(keep bool) *ReconcileIndexesOptions {
+	r.KeepUnlisted = &keep
+	return r
+}
+
+// SetProgressFn sets the value for the ProgressFn field.
+func (r *ReconcileIndexesOptions) SetProgressFn(fn func(IndexProgressEvent)) *ReconcileIndexesOptions {
+	r.ProgressFn = fn
+	return r
+}
+
+// MergeReconcileIndexesOptions combines the given ReconcileIndexesOptions instances into a single
+// *ReconcileIndexesOptions in a last-one-wins fashion.
+//
+// Deprecated: Merging options structs will not be supported in Go Driver 2.0. Users should create a
+// single options struct instead.
+func MergeReconcileIndexesOptions(opts ...*ReconcileIndexesOptions) *ReconcileIndexesOptions {
+	r := ReconcileIndexes()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.KeepUnlisted != nil {
+			r.KeepUnlisted = opt.KeepUnlisted
+		}
+		if opt.ProgressFn != nil {
+			r.ProgressFn = opt.ProgressFn
+		}
+	}
+
+	return r
+}
+
 // IndexOptions represents options that can be used to configure a new index created through the IndexView.CreateOne
 // or IndexView.CreateMany operations.
 type IndexOptions struct {
@@ -231,7 +842,9 @@ type IndexOptions struct {
 	// existing value in the index. The default is false.
 	Unique *bool
 
-	// The index version number, either 0 or 1.
+	// The index version number, either 1 or 2. Version 2 is the default for MongoDB versions >= 3.4. A client-side
+	// error is returned for any other value. The default value is nil, which means that the server-side default will be
+	// used.
 	Version *int32
 
 	// The language that determines the list of stop words and the rules for the stemmer and tokenizer. This option
@@ -250,7 +863,8 @@ type IndexOptions struct {
 	// A document that contains field and weight pairs. The weight is an integer ranging from 1 to 99,999, inclusive,
 	// indicating the significance of the field relative to the other indexed fields in terms of the score. This option
 	// is only applicable for text indexes and is ignored for other index types. The default value is nil, which means
-	// that every field will have a weight of 1.
+	// that every field will have a weight of 1. A TextIndexWeights created with the TextWeights function can be used to
+	// build this document with a stable field order.
 	Weights interface{}
 
 	// The index version number for a 2D sphere index. See https://www.mongodb.com/docs/manual/core/2dsphere/#dsphere-v2 for
@@ -282,7 +896,9 @@ type IndexOptions struct {
 	// For previous server versions, the driver will return an error if this option is used.
 	Collation *Collation
 
-	// A document that defines the wildcard projection for the index.
+	// A document that defines the wildcard projection for the index. An IndexWildcardProjection created with the
+	// WildcardProjection function can be used to build this document and check that it does not mix included and
+	// excluded fields.
 	WildcardProjection interface{}
 
 	// If true, the index will exist on the target collection but will not be used by the query planner when executing
@@ -295,6 +911,239 @@ func Index() *IndexOptions {
 	return &IndexOptions{}
 }
 
+// GeoIndex2DSphere creates a new IndexOptions instance for a 2dsphere index. The SphereVersion field is set to 3, which
+// is the default version for MongoDB versions >= 3.2. The index keys document must use the value "2dsphere" for the
+// location field, e.g. {loc: "2dsphere"}.
+func GeoIndex2DSphere() *IndexOptions {
+	return Index().SetSphereVersion(3)
+}
+
+// GeoIndex2D creates a new IndexOptions instance for a 2d index. The Bits, Min, and Max fields are set to the server
+// defaults of 26, -180.0, and 180.0, and can be changed with the SetBits, SetMin, and SetMax methods. The index keys
+// document must use the value "2d" for the location field, e.g. {loc: "2d"}.
+func GeoIndex2D() *IndexOptions {
+	return Index().SetBits(26).SetMin(-180).SetMax(180)
+}
+
+// TextIndexWeights is an ordered set of field and weight pairs for a text index. It can be assigned to the
+// IndexOptions.Weights field and marshals to a BSON document with the fields in the order they were added.
+type TextIndexWeights struct {
+	fields  []string
+	weights []int32
+}
+
+var _ bson.Marshaler = (*TextIndexWeights)(nil)
+
+// TextWeights creates a new TextIndexWeights instance.
+func TextWeights() *TextIndexWeights {
+	return &TextIndexWeights{}
+}
+
+// Add sets the weight for a field. If the field has already been added, its weight is replaced and its position is
+// unchanged.
+func (w *TextIndexWeights) Add(field string, weight int32) *TextIndexWeights {
+	for i, f := range w.fields {
+		if f == field {
+			w.weights[i] = weight
+			return w
+		}
+	}
+
+	w.fields = append(w.fields, field)
+	w.weights = append(w.weights, weight)
+	return w
+}
+
+// MarshalBSON implements the bson.Marshaler interface.
+func (w *TextIndexWeights) MarshalBSON() ([]byte, error) {
+	idx, doc := bsoncore.AppendDocumentStart(nil)
+	for i, field := range w.fields {
+		doc = bsoncore.AppendInt32Element(doc, field, w.weights[i])
+	}
+	return bsoncore.AppendDocumentEnd(doc, idx)
+}
+
+// IndexWildcardProjection is an ordered set of fields to include in or exclude from a wildcard index. It can be
+// assigned to the IndexOptions.WildcardProjection field and marshals to a BSON document with the fields in the order
+// they were added. A projection can either include or exclude fields, except for the "_id" field, which can be
+// included in an exclusion projection or excluded from an inclusion projection.
+type IndexWildcardProjection struct {
+	fields []string
+	values []int32
+	err    error
+}
+
+var _ bson.Marshaler = (*IndexWildcardProjection)(nil)
+
+// WildcardProjection creates a new IndexWildcardProjection instance.
+func WildcardProjection() *IndexWildcardProjection {
+	return &IndexWildcardProjection{}
+}
+
+// Include adds a field to include in the wildcard index.
+func (p *IndexWildcardProjection) Include(field string) *IndexWildcardProjection {
+	return p.add(field, 1)
+}
+
+// Exclude adds a field to exclude from the wildcard index.
+func (p *IndexWildcardProjection) Exclude(field string) *IndexWildcardProjection {
+	return p.add(field, 0)
+}
+
+func (p *IndexWildcardProjection) add(field string, value int32) *IndexWildcardProjection {
+	if p.err != nil {
+		return p
+	}
+	if field == "" {
+		p.err = errors.New("wildcard projection field names cannot be empty")
+		return p
+	}
+	if field != "_id" {
+		for i, f := range p.fields {
+			if f != "_id" && p.values[i] != value {
+				p.err = fmt.Errorf("wildcard projection cannot mix included and excluded fields, got %q and %q", f,
+					field)
+				return p
+			}
+		}
+	}
+
+	for i, f := range p.fields {
+		if f == field {
+			p.values[i] = value
+			return p
+		}
+	}
+	p.fields = append(p.fields, field)
+	p.values = append(p.values, value)
+	return p
+}
+
+// Err returns the first error caused by an invalid call to Include or Exclude, or nil if the projection is valid.
+func (p *IndexWildcardProjection) Err() error {
+	if p.err == nil && len(p.fields) == 0 {
+		return errors.New("wildcard projection must contain at least one field")
+	}
+	return p.err
+}
+
+// MarshalBSON implements the bson.Marshaler interface. It returns the error from Err if the projection is not valid.
+func (p *IndexWildcardProjection) MarshalBSON() ([]byte, error) {
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+
+	idx, doc := bsoncore.AppendDocumentStart(nil)
+	for i, field := range p.fields {
+		doc = bsoncore.AppendInt32Element(doc, field, p.values[i])
+	}
+	return bsoncore.AppendDocumentEnd(doc, idx)
+}
+
+// Validate checks the options for combinations that the server would reject when creating an index with the given keys
+// document, such as text index options without a text key or a TTL on a compound index. It does not contact the server,
+// so an index that passes validation may still fail to be created.
+func (i *IndexOptions) Validate(keys bson.D) error {
+	if len(keys) == 0 {
+		return errors.New("index keys cannot be empty")
+	}
+
+	types := make(map[string]bool)
+	var hasWildcard bool
+	for _, key := range keys {
+		if key.Key == "" {
+			return errors.New("index key field names cannot be empty")
+		}
+		if key.Key == "$**" || strings.HasSuffix(key.Key, ".$**") {
+			hasWildcard = true
+		}
+
+		var direction float64
+		switch v := key.Value.(type) {
+		case string:
+			types[v] = true
+			continue
+		case int:
+			direction = float64(v)
+		case int32:
+			direction = float64(v)
+		case int64:
+			direction = float64(v)
+		case float64:
+			direction = v
+		default:
+			return fmt.Errorf("index key %q has invalid value type %T", key.Key, key.Value)
+		}
+		if direction == 0 {
+			return fmt.Errorf("index key %q must not have a value of 0", key.Key)
+		}
+	}
+
+	if i == nil {
+		return nil
+	}
+
+	if !types["text"] {
+		switch {
+		case i.Weights != nil:
+			return errors.New("the Weights option requires a text index key")
+		case i.DefaultLanguage != nil:
+			return errors.New("the DefaultLanguage option requires a text index key")
+		case i.LanguageOverride != nil:
+			return errors.New("the LanguageOverride option requires a text index key")
+		case i.TextVersion != nil:
+			return errors.New("the TextVersion option requires a text index key")
+		}
+	}
+	if i.SphereVersion != nil && !types["2dsphere"] {
+		return errors.New("the SphereVersion option requires a 2dsphere index key")
+	}
+	if (i.Bits != nil || i.Min != nil || i.Max != nil) && !types["2d"] {
+		return errors.New("the Bits, Min, and Max options require a 2d index key")
+	}
+	if i.Bits != nil && (*i.Bits < 1 || *i.Bits > 32) {
+		return fmt.Errorf("the Bits option must be between 1 and 32, got %d", *i.Bits)
+	}
+	if i.Min != nil && i.Max != nil && *i.Min >= *i.Max {
+		return fmt.Errorf("the Min option (%v) must be less than the Max option (%v)", *i.Min, *i.Max)
+	}
+	if i.BucketSize != nil {
+		if !types["geoHaystack"] {
+			return errors.New("the BucketSize option requires a geoHaystack index key")
+		}
+		if *i.BucketSize <= 0 {
+			return fmt.Errorf("the BucketSize option must be greater than 0, got %d", *i.BucketSize)
+		}
+	}
+	if i.Version != nil && *i.Version != 1 && *i.Version != 2 {
+		return fmt.Errorf("the Version option must be 1 or 2, got %d", *i.Version)
+	}
+	if i.Collation != nil && i.Collation.Locale == "" {
+		return errors.New("the Collation option must specify a locale")
+	}
+	if i.WildcardProjection != nil && !hasWildcard {
+		return errors.New("the WildcardProjection option requires a wildcard index key")
+	}
+	if i.ExpireAfterSeconds != nil {
+		if len(keys) != 1 {
+			return errors.New("the ExpireAfterSeconds option requires an index on a single field")
+		}
+		if keys[0].Key == "_id" {
+			return errors.New("the ExpireAfterSeconds option cannot be used on the _id field")
+		}
+		if *i.ExpireAfterSeconds < 0 {
+			return fmt.Errorf("the ExpireAfterSeconds option must not be negative, got %d", *i.ExpireAfterSeconds)
+		}
+	}
+	if i.Unique != nil && *i.Unique && types["hashed"] {
+		return errors.New("hashed indexes cannot be unique")
+	}
+	if i.Sparse != nil && *i.Sparse && i.PartialFilterExpression != nil {
+		return errors.New("the Sparse and PartialFilterExpression options cannot be used together")
+	}
+	return nil
+}
+
 // SetBackground sets value for the Background field.
 //
 // Deprecated: This option has been deprecated in MongoDB version 4.2.
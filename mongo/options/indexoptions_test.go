@@ -0,0 +1,203 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/require"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+func TestTextIndexWeights(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		weights *TextIndexWeights
+		want    bsoncore.Document
+	}{
+		{
+			name:    "empty",
+			weights: TextWeights(),
+			want:    bsoncore.BuildDocumentFromElements(nil),
+		},
+		{
+			name:    "insertion order",
+			weights: TextWeights().Add("title", 10).Add("body", 1).Add("abstract", 5),
+			want: bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendInt32Element(nil, "title", 10),
+				bsoncore.AppendInt32Element(nil, "body", 1),
+				bsoncore.AppendInt32Element(nil, "abstract", 5),
+			),
+		},
+		{
+			name:    "replaced weight keeps position",
+			weights: TextWeights().Add("title", 10).Add("body", 1).Add("title", 20),
+			want: bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendInt32Element(nil, "title", 20),
+				bsoncore.AppendInt32Element(nil, "body", 1),
+			),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // Capture range variable.
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			for i := 0; i < 3; i++ {
+				got, err := bson.Marshal(tc.weights)
+				require.NoError(t, err, "Marshal error")
+				assert.Equal(t, tc.want, bsoncore.Document(got), "expected weights document %v, got %v", tc.want,
+					bsoncore.Document(got))
+			}
+		})
+	}
+	t.Run("assignable to IndexOptions", func(t *testing.T) {
+		t.Parallel()
+
+		opts := Index().SetWeights(TextWeights().Add("title", 10))
+		got, err := bson.Marshal(opts.Weights)
+		require.NoError(t, err, "Marshal error")
+
+		want := bsoncore.Document(bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendInt32Element(nil, "title", 10)))
+		assert.Equal(t, want, bsoncore.Document(got), "expected weights document %v, got %v", want, bsoncore.Document(got))
+	})
+}
+
+func TestIndexWildcardProjection(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		projection *IndexWildcardProjection
+		want       bsoncore.Document
+		wantErr    bool
+	}{
+		{
+			name:       "include",
+			projection: WildcardProjection().Include("a.b").Include("c"),
+			want: bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendInt32Element(nil, "a.b", 1),
+				bsoncore.AppendInt32Element(nil, "c", 1),
+			),
+		},
+		{
+			name:       "exclude",
+			projection: WildcardProjection().Exclude("a").Exclude("b.c"),
+			want: bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendInt32Element(nil, "a", 0),
+				bsoncore.AppendInt32Element(nil, "b.c", 0),
+			),
+		},
+		{
+			name:       "include with excluded _id",
+			projection: WildcardProjection().Include("a").Exclude("_id"),
+			want: bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendInt32Element(nil, "a", 1),
+				bsoncore.AppendInt32Element(nil, "_id", 0),
+			),
+		},
+		{
+			name:       "exclude with included _id",
+			projection: WildcardProjection().Include("_id").Exclude("a"),
+			want: bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendInt32Element(nil, "_id", 1),
+				bsoncore.AppendInt32Element(nil, "a", 0),
+			),
+		},
+		{
+			name:       "mixed",
+			projection: WildcardProjection().Include("a").Exclude("b"),
+			wantErr:    true,
+		},
+		{
+			name:       "empty",
+			projection: WildcardProjection(),
+			wantErr:    true,
+		},
+		{
+			name:       "empty field name",
+			projection: WildcardProjection().Include(""),
+			wantErr:    true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // Capture range variable.
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := bson.Marshal(tc.projection)
+			if tc.wantErr {
+				assert.NotNil(t, err, "expected Marshal error, got nil")
+				assert.NotNil(t, tc.projection.Err(), "expected Err to return an error, got nil")
+				return
+			}
+			require.NoError(t, err, "Marshal error")
+			assert.Nil(t, tc.projection.Err(), "Err error: %v", tc.projection.Err())
+			assert.Equal(t, tc.want, bsoncore.Document(got), "expected projection document %v, got %v", tc.want,
+				bsoncore.Document(got))
+		})
+	}
+}
+
+func TestIndexOptionsValidate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		opts    *IndexOptions
+		keys    bson.D
+		wantErr bool
+	}{
+		{"nil options", nil, bson.D{{"a", 1}}, false},
+		{"empty keys", Index(), bson.D{}, true},
+		{"zero direction", Index(), bson.D{{"a", 0}}, true},
+		{"invalid key value", Index(), bson.D{{"a", true}}, true},
+		{"text weights", Index().SetWeights(TextWeights().Add("title", 10)), bson.D{{"title", "text"}}, false},
+		{"weights without text key", Index().SetWeights(bson.D{{"title", 10}}), bson.D{{"title", 1}}, true},
+		{"language without text key", Index().SetDefaultLanguage("english"), bson.D{{"title", 1}}, true},
+		{"2dsphere", GeoIndex2DSphere(), bson.D{{"loc", "2dsphere"}}, false},
+		{"sphere version without 2dsphere key", GeoIndex2DSphere(), bson.D{{"loc", "2d"}}, true},
+		{"2d", GeoIndex2D(), bson.D{{"loc", "2d"}}, false},
+		{"2d options without 2d key", GeoIndex2D(), bson.D{{"loc", 1}}, true},
+		{"bits out of range", GeoIndex2D().SetBits(33), bson.D{{"loc", "2d"}}, true},
+		{"min not less than max", GeoIndex2D().SetMin(10).SetMax(10), bson.D{{"loc", "2d"}}, true},
+		{"ttl", Index().SetExpireAfterSeconds(60), bson.D{{"createdAt", 1}}, false},
+		{"ttl on compound index", Index().SetExpireAfterSeconds(60), bson.D{{"a", 1}, {"b", 1}}, true},
+		{"ttl on _id", Index().SetExpireAfterSeconds(60), bson.D{{"_id", 1}}, true},
+		{"wildcard projection", Index().SetWildcardProjection(bson.D{{"a", 1}}), bson.D{{"$**", 1}}, false},
+		{"wildcard projection without wildcard key", Index().SetWildcardProjection(bson.D{{"a", 1}}), bson.D{{"a", 1}}, true},
+		{"unique hashed", Index().SetUnique(true), bson.D{{"a", "hashed"}}, true},
+		{"sparse and partial", Index().SetSparse(true).SetPartialFilterExpression(bson.D{{"a", 1}}), bson.D{{"a", 1}}, true},
+		{"unique partial", Index().SetUnique(true).SetPartialFilterExpression(bson.D{{"a", 1}}), bson.D{{"a", 1}}, false},
+		{"version 1", Index().SetVersion(1), bson.D{{"a", 1}}, false},
+		{"version 2", Index().SetVersion(2), bson.D{{"a", 1}}, false},
+		{"version 0", Index().SetVersion(0), bson.D{{"a", 1}}, true},
+		{"version 3", Index().SetVersion(3), bson.D{{"a", 1}}, true},
+		{"collation", Index().SetCollation(NewCollation("en").SetStrength(2)), bson.D{{"a", 1}}, false},
+		{"collation without locale", Index().SetCollation((&Collation{}).SetStrength(2)), bson.D{{"a", 1}}, true},
+	}
+	for _, tc := range testCases {
+		tc := tc // Capture range variable.
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.opts.Validate(tc.keys)
+			if tc.wantErr {
+				assert.NotNil(t, err, "expected Validate error, got nil")
+				return
+			}
+			assert.Nil(t, err, "Validate error: %v", err)
+		})
+	}
+}
@@ -66,6 +66,66 @@ func (co *Collation) ToDocument() bson.Raw {
 	return doc
 }
 
+// NewCollation creates a new Collation instance for the given locale. The locale is required by the server, so it must
+// be a valid ICU locale or "simple" for binary comparison.
+func NewCollation(locale string) *Collation {
+	return &Collation{Locale: locale}
+}
+
+// SetLocale sets the value for the Locale field.
+func (co *Collation) SetLocale(locale string) *Collation {
+	co.Locale = locale
+	return co
+}
+
+// SetCaseLevel sets the value for the CaseLevel field.
+func (co *Collation) SetCaseLevel(caseLevel bool) *Collation {
+	co.CaseLevel = caseLevel
+	return co
+}
+
+// SetCaseFirst sets the value for the CaseFirst field.
+func (co *Collation) SetCaseFirst(caseFirst string) *Collation {
+	co.CaseFirst = caseFirst
+	return co
+}
+
+// SetStrength sets the value for the Strength field.
+func (co *Collation) SetStrength(strength int) *Collation {
+	co.Strength = strength
+	return co
+}
+
+// SetNumericOrdering sets the value for the NumericOrdering field.
+func (co *Collation) SetNumericOrdering(numericOrdering bool) *Collation {
+	co.NumericOrdering = numericOrdering
+	return co
+}
+
+// SetAlternate sets the value for the Alternate field.
+func (co *Collation) SetAlternate(alternate string) *Collation {
+	co.Alternate = alternate
+	return co
+}
+
+// SetMaxVariable sets the value for the MaxVariable field.
+func (co *Collation) SetMaxVariable(maxVariable string) *Collation {
+	co.MaxVariable = maxVariable
+	return co
+}
+
+// SetNormalization sets the value for the Normalization field.
+func (co *Collation) SetNormalization(normalization bool) *Collation {
+	co.Normalization = normalization
+	return co
+}
+
+// SetBackwards sets the value for the Backwards field.
+func (co *Collation) SetBackwards(backwards bool) *Collation {
+	co.Backwards = backwards
+	return co
+}
+
 // CursorType specifies whether a cursor should close when the last data is retrieved. See
 // NonTailable, Tailable, and TailableAwait.
 type CursorType int8
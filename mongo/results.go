@@ -8,9 +8,12 @@ package mongo
 
 import (
 	"fmt"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/operation"
 )
 
@@ -185,6 +188,37 @@ type IndexSpecification struct {
 
 	// The clustered index.
 	Clustered *bool
+
+	// The UUID of the index build if the index is still being built, or nil if the index is ready. This is only set
+	// if the ListIndexesOptions.IncludeBuildInfo option is used.
+	BuildUUID *primitive.Binary
+}
+
+// Equal returns true if i and other describe the same index. The Name, KeysDocument, ExpireAfterSeconds, Sparse,
+// Unique, and Clustered fields are compared. The Version, Namespace, and BuildUUID fields are set by the server and are
+// ignored, so specifications listed from different deployments or collections can be compared. Keys are compared in
+// order and numeric key values of different types are equal if they have the same value. An unset boolean option is
+// equal to false.
+func (i IndexSpecification) Equal(other IndexSpecification) bool {
+	if i.Name != other.Name {
+		return false
+	}
+	if !indexDocumentsEqual(bsoncore.Document(i.KeysDocument), bsoncore.Document(other.KeysDocument)) {
+		return false
+	}
+
+	switch {
+	case i.ExpireAfterSeconds == nil && other.ExpireAfterSeconds == nil:
+	case i.ExpireAfterSeconds == nil || other.ExpireAfterSeconds == nil:
+		return false
+	case *i.ExpireAfterSeconds != *other.ExpireAfterSeconds:
+		return false
+	}
+
+	boolValue := func(b *bool) bool { return b != nil && *b }
+	return boolValue(i.Sparse) == boolValue(other.Sparse) &&
+		boolValue(i.Unique) == boolValue(other.Unique) &&
+		boolValue(i.Clustered) == boolValue(other.Clustered)
 }
 
 var _ bson.Unmarshaler = (*IndexSpecification)(nil)
@@ -204,6 +238,29 @@ type unmarshalIndexSpecification struct {
 //
 // Deprecated: Unmarshaling an IndexSpecification from BSON will not be supported in Go Driver 2.0.
 func (i *IndexSpecification) UnmarshalBSON(data []byte) error {
+	// When build information is requested, the specification is nested in a "spec" field alongside the build UUID
+	// of an index that is being built.
+	if spec, ok := bson.Raw(data).Lookup("spec").DocumentOK(); ok {
+		var info struct {
+			BuildUUID      *primitive.Binary `bson:"buildUUID"`
+			IndexBuildInfo struct {
+				BuildUUID *primitive.Binary `bson:"buildUUID"`
+			} `bson:"indexBuildInfo"`
+		}
+		if err := bson.Unmarshal(data, &info); err != nil {
+			return err
+		}
+		if err := i.UnmarshalBSON(spec); err != nil {
+			return err
+		}
+
+		i.BuildUUID = info.IndexBuildInfo.BuildUUID
+		if i.BuildUUID == nil {
+			i.BuildUUID = info.BuildUUID
+		}
+		return nil
+	}
+
 	var temp unmarshalIndexSpecification
 	if err := bson.Unmarshal(data, &temp); err != nil {
 		return err
@@ -217,9 +274,121 @@ func (i *IndexSpecification) UnmarshalBSON(data []byte) error {
 	i.Sparse = temp.Sparse
 	i.Unique = temp.Unique
 	i.Clustered = temp.Clustered
+	i.BuildUUID = nil
 	return nil
 }
 
+// CreateManyResult is the result type returned by IndexView.CreateManyWithResult.
+type CreateManyResult struct {
+	// The names of the indexes, in the same order as the IndexModels passed to CreateManyWithResult. Exact duplicate
+	// models are only sent once, but each of them has an entry with the shared name.
+	Names []string
+
+	// True if the collection did not exist and was created by the createIndexes command.
+	CreatedCollectionAutomatically bool
+
+	// The number of indexes on the collection before the command was executed.
+	IndexesBefore int32
+
+	// The number of indexes on the collection after the command was executed.
+	IndexesAfter int32
+
+	// The write concern error reported by the server, or nil if the write concern was satisfied. For example, with a
+	// "majority" write concern, a nil value means the index builds were acknowledged by a majority of the replica set.
+	WriteConcernError *WriteConcernError
+
+	// The number of documents scanned while building the indexes. This is nil unless the server includes build
+	// statistics in its reply, which most server versions do not.
+	DocsScanned *int64
+
+	// The number of index keys inserted while building the indexes. This is nil unless the server includes build
+	// statistics in its reply, which most server versions do not.
+	KeysInserted *int64
+
+	// The description of the server that executed the createIndexes command, including its address, kind, and wire
+	// version. If the indexes were created in several batches, this is the server that executed the last batch.
+	Server description.Server
+}
+
+// NamedIndexModel is an IndexModel paired with the name of its index. It is returned by IndexView.CreateManyNamed.
+type NamedIndexModel struct {
+	// The model passed to CreateManyNamed.
+	Model IndexModel
+
+	// The name of the index created for the model.
+	Name string
+}
+
+func newCreateManyResult(names []string, res operation.CreateIndexesResult) *CreateManyResult {
+	return &CreateManyResult{
+		Names:                          names,
+		CreatedCollectionAutomatically: res.CreatedCollectionAutomatically,
+		IndexesBefore:                  res.IndexesBefore,
+		IndexesAfter:                   res.IndexesAfter,
+		DocsScanned:                    res.DocsScanned,
+		KeysInserted:                   res.KeysInserted,
+	}
+}
+
+// DropIndexesResult is the result type returned by IndexView.DropOneWithResult.
+type DropIndexesResult struct {
+	// The number of indexes that existed before the index was dropped.
+	NIndexesWas int32
+
+	// The description of the server that executed the dropIndexes command, including its address, kind, and wire
+	// version.
+	Server description.Server
+}
+
+// IndexModificationResult is the result type returned by IndexView.Modify. Each pair of fields holds the previous and
+// new values of an index option as reported by the server. Both fields of a pair are nil if the option was not changed.
+type IndexModificationResult struct {
+	ExpireAfterSecondsOld *int32 `bson:"expireAfterSeconds_old"`
+	ExpireAfterSecondsNew *int32 `bson:"expireAfterSeconds_new"`
+	HiddenOld             *bool  `bson:"hidden_old"`
+	HiddenNew             *bool  `bson:"hidden_new"`
+	PrepareUniqueOld      *bool  `bson:"prepareUnique_old"`
+	PrepareUniqueNew      *bool  `bson:"prepareUnique_new"`
+}
+
+// DropResult is the result of dropping a single index with IndexView.DropMany.
+type DropResult struct {
+	// True if the index existed and was dropped. False if the index was already absent.
+	Dropped bool
+}
+
+// IndexUsage represents the usage statistics of an index as reported by the $indexStats aggregation stage. This type is
+// returned by the IndexView.Usage function.
+type IndexUsage struct {
+	// The index name.
+	Name string
+
+	// The keys specification document for the index.
+	KeysDocument bson.Raw
+
+	// The host and port of the mongod that reported the statistics. In a sharded cluster, there is one IndexUsage for
+	// each shard that has the index.
+	Host string
+
+	// The number of operations that used the index since Since.
+	Ops int64
+
+	// The time at which the server began gathering statistics for the index, which is reset when the server restarts
+	// or the index is rebuilt.
+	Since time.Time
+}
+
+// unmarshalIndexUsage is used to unmarshal the documents returned by the $indexStats aggregation stage.
+type unmarshalIndexUsage struct {
+	Name         string   `bson:"name"`
+	KeysDocument bson.Raw `bson:"key"`
+	Host         string   `bson:"host"`
+	Accesses     struct {
+		Ops   int64     `bson:"ops"`
+		Since time.Time `bson:"since"`
+	} `bson:"accesses"`
+}
+
 // CollectionSpecification represents a collection in a database. This type is returned by the
 // Database.ListCollectionSpecifications function.
 type CollectionSpecification struct {
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/internal/assert"
 )
 
@@ -79,4 +80,121 @@ func TestResults(t *testing.T) {
 			assert.Equal(t, int32(3), upsertedID, "expected upsertedID 3, got %v", upsertedID)
 		})
 	})
+	t.Run("index specification with build info", func(t *testing.T) {
+		buildUUID := primitive.Binary{Subtype: 4, Data: []byte("0123456789abcdef")}
+		spec := bson.D{{"v", 2}, {"key", bson.D{{"foo", 1}}}, {"name", "foo_1"}}
+
+		testCases := []struct {
+			name      string
+			doc       bson.D
+			buildUUID *primitive.Binary
+		}{
+			{"ready index", spec, nil},
+			{"ready index with build info", bson.D{{"spec", spec}}, nil},
+			{"in-progress index", bson.D{{"spec", spec}, {"indexBuildInfo", bson.D{{"buildUUID", buildUUID}}}}, &buildUUID},
+			{"in-progress index with legacy build UUID", bson.D{{"spec", spec}, {"buildUUID", buildUUID}}, &buildUUID},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				b, err := bson.Marshal(tc.doc)
+				assert.Nil(t, err, "Marshal error: %v", err)
+
+				var got IndexSpecification
+				err = bson.Unmarshal(b, &got)
+				assert.Nil(t, err, "Unmarshal error: %v", err)
+				assert.Equal(t, "foo_1", got.Name, "expected name %q, got %q", "foo_1", got.Name)
+				assert.Equal(t, int32(2), got.Version, "expected version 2, got %v", got.Version)
+				assert.Equal(t, tc.buildUUID, got.BuildUUID, "expected build UUID %v, got %v", tc.buildUUID, got.BuildUUID)
+			})
+		}
+	})
+	t.Run("index specification equal", func(t *testing.T) {
+		pbool := func(b bool) *bool { return &b }
+		pint32 := func(i int32) *int32 { return &i }
+		keys := func(doc bson.D) bson.Raw {
+			b, err := bson.Marshal(doc)
+			assert.Nil(t, err, "Marshal error: %v", err)
+			return b
+		}
+
+		base := IndexSpecification{
+			Name:               "foo_1",
+			Namespace:          "db.coll",
+			KeysDocument:       keys(bson.D{{"foo", int32(1)}}),
+			Version:            2,
+			ExpireAfterSeconds: pint32(60),
+		}
+
+		testCases := []struct {
+			name  string
+			other IndexSpecification
+			equal bool
+		}{
+			{"identical", base, true},
+			{
+				"different version and namespace",
+				IndexSpecification{
+					Name:               "foo_1",
+					Namespace:          "otherdb.othercoll",
+					KeysDocument:       keys(bson.D{{"foo", int32(1)}}),
+					Version:            1,
+					ExpireAfterSeconds: pint32(60),
+				},
+				true,
+			},
+			{
+				"numeric key types",
+				IndexSpecification{
+					Name:               "foo_1",
+					KeysDocument:       keys(bson.D{{"foo", 1.0}}),
+					ExpireAfterSeconds: pint32(60),
+				},
+				true,
+			},
+			{
+				"unset and false unique",
+				IndexSpecification{
+					Name:               "foo_1",
+					KeysDocument:       keys(bson.D{{"foo", int32(1)}}),
+					ExpireAfterSeconds: pint32(60),
+					Unique:             pbool(false),
+				},
+				true,
+			},
+			{
+				"different unique",
+				IndexSpecification{
+					Name:               "foo_1",
+					KeysDocument:       keys(bson.D{{"foo", int32(1)}}),
+					ExpireAfterSeconds: pint32(60),
+					Unique:             pbool(true),
+				},
+				false,
+			},
+			{
+				"different expireAfterSeconds",
+				IndexSpecification{
+					Name:               "foo_1",
+					KeysDocument:       keys(bson.D{{"foo", int32(1)}}),
+					ExpireAfterSeconds: pint32(120),
+				},
+				false,
+			},
+			{
+				"different key order",
+				IndexSpecification{
+					Name:               "foo_1",
+					KeysDocument:       keys(bson.D{{"bar", int32(1)}, {"foo", int32(1)}}),
+					ExpireAfterSeconds: pint32(60),
+				},
+				false,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				assert.Equal(t, tc.equal, base.Equal(tc.other), "expected Equal to return %v", tc.equal)
+				assert.Equal(t, tc.equal, tc.other.Equal(base), "expected Equal to be symmetric")
+			})
+		}
+	})
 }
@@ -35,6 +35,33 @@ type SearchIndexModel struct {
 	Options *options.SearchIndexesOptions
 }
 
+// Explain runs the $listSearchIndexes aggregation used by SearchIndexView.List under the explain command and returns
+// the query plan reported by the server.
+//
+// The searchIdxOpts parameter selects the search indexes that the explained aggregation lists, as it does for
+// SearchIndexView.List. If it is nil or its Name field is not set, all search indexes are listed. Otherwise, only the
+// index with that name is listed.
+//
+// The opts parameter can be used to specify the same options that are passed to SearchIndexView.List (see the
+// options.ListSearchIndexesOptions documentation).
+func (siv SearchIndexView) Explain(
+	ctx context.Context,
+	searchIdxOpts *options.SearchIndexesOptions,
+	opts ...*options.ListSearchIndexesOptions,
+) (bson.Raw, error) {
+	index := bson.D{}
+	if searchIdxOpts != nil && searchIdxOpts.Name != nil {
+		index = bson.D{{"name", *searchIdxOpts.Name}}
+	}
+
+	aggregateOpts := make([]*options.AggregateOptions, len(opts))
+	for i, opt := range opts {
+		aggregateOpts[i] = opt.AggregateOpts
+	}
+
+	return explainAggregate(ctx, siv.coll, Pipeline{{{"$listSearchIndexes", index}}}, aggregateOpts...)
+}
+
 // List executes a listSearchIndexes command and returns a cursor over the search indexes in the collection.
 //
 // The name parameter specifies the index name. A nil pointer matches all indexes.
@@ -303,6 +303,11 @@ func (bc *BatchCursor) Server() Server {
 	return bc.server
 }
 
+// ServerDescription returns the description of the server that the command that created this cursor was sent to.
+func (bc *BatchCursor) ServerDescription() description.Server {
+	return bc.serverDescription
+}
+
 func (bc *BatchCursor) clearBatch() {
 	bc.currentBatch.Data = bc.currentBatch.Data[:0]
 }
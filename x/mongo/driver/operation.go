@@ -310,6 +310,10 @@ type Operation struct {
 	// OP_MSG as well as for logging server selection data.
 	Name string
 
+	// OperationName is an application-provided name for the operation that is
+	// included in command monitoring events. It is not sent to the server.
+	OperationName string
+
 	// OmitCSOTMaxTimeMS omits the automatically-calculated "maxTimeMS" from the
 	// command when CSOT is enabled. It does not effect "maxTimeMS" set by
 	// [Operation.MaxTime].
@@ -1989,6 +1993,7 @@ func (op Operation) publishStartedEvent(ctx context.Context, info startedInforma
 			ServerConnectionID:   convertInt64PtrToInt32Ptr(info.serverConnID),
 			ServerConnectionID64: info.serverConnID,
 			ServiceID:            info.serviceID,
+			OperationName:        op.OperationName,
 		}
 		op.CommandMonitor.Started(ctx, started)
 	}
@@ -2070,6 +2075,7 @@ func (op Operation) publishFinishedEvent(ctx context.Context, info finishedInfor
 		ServerConnectionID:   convertInt64PtrToInt32Ptr(info.serverConnID),
 		ServerConnectionID64: info.serverConnID,
 		ServiceID:            info.serviceID,
+		OperationName:        op.OperationName,
 	}
 
 	if info.success() {
@@ -24,21 +24,25 @@ import (
 
 // CreateIndexes performs a createIndexes operation.
 type CreateIndexes struct {
-	commitQuorum bsoncore.Value
-	indexes      bsoncore.Document
-	maxTime      *time.Duration
-	session      *session.Client
-	clock        *session.ClusterClock
-	collection   string
-	monitor      *event.CommandMonitor
-	crypt        driver.Crypt
-	database     string
-	deployment   driver.Deployment
-	selector     description.ServerSelector
-	writeConcern *writeconcern.WriteConcern
-	result       CreateIndexesResult
-	serverAPI    *driver.ServerAPIOptions
-	timeout      *time.Duration
+	comment           bsoncore.Value
+	commitQuorum      bsoncore.Value
+	foreground        *bool
+	indexes           bsoncore.Document
+	maxTime           *time.Duration
+	session           *session.Client
+	clock             *session.ClusterClock
+	collection        string
+	monitor           *event.CommandMonitor
+	crypt             driver.Crypt
+	database          string
+	deployment        driver.Deployment
+	selector          description.ServerSelector
+	writeConcern      *writeconcern.WriteConcern
+	result            CreateIndexesResult
+	serverDescription description.Server
+	serverAPI         *driver.ServerAPIOptions
+	timeout           *time.Duration
+	operationName     string
 }
 
 // CreateIndexesResult represents a createIndexes result returned by the server.
@@ -49,6 +53,10 @@ type CreateIndexesResult struct {
 	IndexesAfter int32
 	// The number of indexes existing before this command.
 	IndexesBefore int32
+	// The number of documents scanned by the index build, if reported by the server.
+	DocsScanned *int64
+	// The number of index keys inserted by the index build, if reported by the server.
+	KeysInserted *int64
 }
 
 func buildCreateIndexesResult(response bsoncore.Document) (CreateIndexesResult, error) {
@@ -65,18 +73,30 @@ func buildCreateIndexesResult(response bsoncore.Document) (CreateIndexesResult,
 			if !ok {
 				return cir, fmt.Errorf("response field 'createdCollectionAutomatically' is type bool, but received BSON type %s", element.Value().Type)
 			}
-		case "indexesAfter":
+		case "indexesAfter", "numIndexesAfter":
 			var ok bool
 			cir.IndexesAfter, ok = element.Value().AsInt32OK()
 			if !ok {
-				return cir, fmt.Errorf("response field 'indexesAfter' is type int32, but received BSON type %s", element.Value().Type)
+				return cir, fmt.Errorf("response field '%s' is type int32, but received BSON type %s", element.Key(), element.Value().Type)
 			}
-		case "indexesBefore":
+		case "indexesBefore", "numIndexesBefore":
 			var ok bool
 			cir.IndexesBefore, ok = element.Value().AsInt32OK()
 			if !ok {
-				return cir, fmt.Errorf("response field 'indexesBefore' is type int32, but received BSON type %s", element.Value().Type)
+				return cir, fmt.Errorf("response field '%s' is type int32, but received BSON type %s", element.Key(), element.Value().Type)
 			}
+		case "docsScanned":
+			docsScanned, ok := element.Value().AsInt64OK()
+			if !ok {
+				return cir, fmt.Errorf("response field 'docsScanned' is type int64, but received BSON type %s", element.Value().Type)
+			}
+			cir.DocsScanned = &docsScanned
+		case "keysInserted":
+			keysInserted, ok := element.Value().AsInt64OK()
+			if !ok {
+				return cir, fmt.Errorf("response field 'keysInserted' is type int64, but received BSON type %s", element.Value().Type)
+			}
+			cir.KeysInserted = &keysInserted
 		}
 	}
 	return cir, nil
@@ -92,8 +112,13 @@ func NewCreateIndexes(indexes bsoncore.Document) *CreateIndexes {
 // Result returns the result of executing this operation.
 func (ci *CreateIndexes) Result() CreateIndexesResult { return ci.result }
 
+// ServerDescription returns the description of the server that executed this operation. It is the zero value if the
+// operation has not received a response.
+func (ci *CreateIndexes) ServerDescription() description.Server { return ci.serverDescription }
+
 func (ci *CreateIndexes) processResponse(info driver.ResponseInfo) error {
 	var err error
+	ci.serverDescription = info.ConnectionDescription
 	ci.result, err = buildCreateIndexesResult(info.ServerResponse)
 	return err
 }
@@ -119,6 +144,7 @@ func (ci *CreateIndexes) Execute(ctx context.Context) error {
 		ServerAPI:         ci.serverAPI,
 		Timeout:           ci.timeout,
 		Name:              driverutil.CreateIndexesOp,
+		OperationName:     ci.operationName,
 	}.Execute(ctx)
 
 }
@@ -131,12 +157,35 @@ func (ci *CreateIndexes) command(dst []byte, desc description.SelectedServer) ([
 		}
 		dst = bsoncore.AppendValueElement(dst, "commitQuorum", ci.commitQuorum)
 	}
+	if ci.foreground != nil && *ci.foreground {
+		if desc.WireVersion != nil && desc.WireVersion.Max >= 8 {
+			return nil, errors.New("foreground index builds are not supported on server wire version 8 or above")
+		}
+	}
+	if ci.session.TransactionRunning() {
+		if desc.WireVersion == nil || !desc.WireVersion.Includes(9) {
+			return nil, errors.New("creating indexes in a transaction requires a minimum server wire version of 9")
+		}
+	}
 	if ci.indexes != nil {
 		dst = bsoncore.AppendArrayElement(dst, "indexes", ci.indexes)
 	}
+	if ci.comment.Type != bsontype.Type(0) {
+		dst = bsoncore.AppendValueElement(dst, "comment", ci.comment)
+	}
 	return dst, nil
 }
 
+// Comment sets a value to help trace an operation.
+func (ci *CreateIndexes) Comment(comment bsoncore.Value) *CreateIndexes {
+	if ci == nil {
+		ci = new(CreateIndexes)
+	}
+
+	ci.comment = comment
+	return ci
+}
+
 // CommitQuorum specifies the number of data-bearing members of a replica set, including the primary, that must
 // complete the index builds successfully before the primary marks the indexes as ready. This should either be a
 // string or int32 value.
@@ -149,6 +198,17 @@ func (ci *CreateIndexes) CommitQuorum(commitQuorum bsoncore.Value) *CreateIndexe
 	return ci
 }
 
+// Foreground specifies that the indexes must be built in the foreground. Servers with a wire version of 8 or above
+// always use hybrid index builds, so an error is returned when the command is sent to one of those servers.
+func (ci *CreateIndexes) Foreground(foreground bool) *CreateIndexes {
+	if ci == nil {
+		ci = new(CreateIndexes)
+	}
+
+	ci.foreground = &foreground
+	return ci
+}
+
 // Indexes specifies an array containing index specification documents for the indexes being created.
 func (ci *CreateIndexes) Indexes(indexes bsoncore.Document) *CreateIndexes {
 	if ci == nil {
@@ -278,3 +338,13 @@ func (ci *CreateIndexes) Timeout(timeout *time.Duration) *CreateIndexes {
 	ci.timeout = timeout
 	return ci
 }
+
+// OperationName sets an application-provided name for this operation that is included in command monitoring events.
+func (ci *CreateIndexes) OperationName(name string) *CreateIndexes {
+	if ci == nil {
+		ci = new(CreateIndexes)
+	}
+
+	ci.operationName = name
+	return ci
+}
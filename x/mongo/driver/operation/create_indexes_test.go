@@ -0,0 +1,136 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package operation
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/require"
+	"go.mongodb.org/mongo-driver/internal/uuid"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
+)
+
+func TestCreateIndexesInTransaction(t *testing.T) {
+	t.Parallel()
+
+	indexes := bsoncore.NewArrayBuilder().
+		AppendDocument(bsoncore.NewDocumentBuilder().
+			AppendDocument("key", bsoncore.NewDocumentBuilder().AppendInt32("foo", 1).Build()).
+			AppendString("name", "foo_1").
+			Build()).
+		Build()
+
+	newTransactionSession := func(t *testing.T) *session.Client {
+		t.Helper()
+
+		id, err := uuid.New()
+		require.NoError(t, err, "uuid.New error")
+		sess, err := session.NewClientSession(session.NewPool(nil), id)
+		require.NoError(t, err, "NewClientSession error")
+		err = sess.StartTransaction(nil)
+		require.NoError(t, err, "StartTransaction error")
+		return sess
+	}
+
+	testCases := []struct {
+		name        string
+		wireVersion *description.VersionRange
+		wantErr     bool
+	}{
+		{"unsupported server", &description.VersionRange{Min: 0, Max: 8}, true},
+		{"unknown wire version", nil, true},
+		{"supported server", &description.VersionRange{Min: 0, Max: 9}, false},
+	}
+	for _, tc := range testCases {
+		tc := tc // Capture range variable.
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			op := NewCreateIndexes(bsoncore.Document(indexes)).Collection("coll").Session(newTransactionSession(t))
+			desc := description.SelectedServer{Server: description.Server{WireVersion: tc.wireVersion}}
+
+			_, err := op.command(nil, desc)
+			if tc.wantErr {
+				assert.NotNil(t, err, "expected command error, got nil")
+				return
+			}
+			assert.Nil(t, err, "command error: %v", err)
+		})
+	}
+	t.Run("outside a transaction", func(t *testing.T) {
+		t.Parallel()
+
+		op := NewCreateIndexes(bsoncore.Document(indexes)).Collection("coll")
+		desc := description.SelectedServer{Server: description.Server{WireVersion: &description.VersionRange{Min: 0, Max: 8}}}
+
+		_, err := op.command(nil, desc)
+		assert.Nil(t, err, "command error: %v", err)
+	})
+}
+
+func TestBuildCreateIndexesResult(t *testing.T) {
+	t.Parallel()
+
+	int64Ptr := func(i int64) *int64 { return &i }
+
+	testCases := []struct {
+		name    string
+		reply   bsoncore.Document
+		want    CreateIndexesResult
+		wantErr bool
+	}{
+		{
+			name: "without build stats",
+			reply: bsoncore.NewDocumentBuilder().
+				AppendInt32("numIndexesBefore", 1).
+				AppendInt32("numIndexesAfter", 2).
+				AppendDouble("ok", 1).
+				Build(),
+			want: CreateIndexesResult{IndexesBefore: 1, IndexesAfter: 2},
+		},
+		{
+			name: "with build stats",
+			reply: bsoncore.NewDocumentBuilder().
+				AppendInt32("numIndexesBefore", 1).
+				AppendInt32("numIndexesAfter", 2).
+				AppendInt32("docsScanned", 100).
+				AppendInt64("keysInserted", 250).
+				AppendDouble("ok", 1).
+				Build(),
+			want: CreateIndexesResult{
+				IndexesBefore: 1,
+				IndexesAfter:  2,
+				DocsScanned:   int64Ptr(100),
+				KeysInserted:  int64Ptr(250),
+			},
+		},
+		{
+			name:    "invalid build stat type",
+			reply:   bsoncore.NewDocumentBuilder().AppendString("docsScanned", "100").Build(),
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // Capture range variable.
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := buildCreateIndexesResult(tc.reply)
+			if tc.wantErr {
+				assert.NotNil(t, err, "expected buildCreateIndexesResult error, got nil")
+				return
+			}
+			require.NoError(t, err, "buildCreateIndexesResult error")
+			assert.Equal(t, tc.want, got, "expected result %+v, got %+v", tc.want, got)
+		})
+	}
+}
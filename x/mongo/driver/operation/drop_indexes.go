@@ -23,20 +23,22 @@ import (
 
 // DropIndexes performs an dropIndexes operation.
 type DropIndexes struct {
-	index        *string
-	maxTime      *time.Duration
-	session      *session.Client
-	clock        *session.ClusterClock
-	collection   string
-	monitor      *event.CommandMonitor
-	crypt        driver.Crypt
-	database     string
-	deployment   driver.Deployment
-	selector     description.ServerSelector
-	writeConcern *writeconcern.WriteConcern
-	result       DropIndexesResult
-	serverAPI    *driver.ServerAPIOptions
-	timeout      *time.Duration
+	index             *string
+	maxTime           *time.Duration
+	session           *session.Client
+	clock             *session.ClusterClock
+	collection        string
+	monitor           *event.CommandMonitor
+	crypt             driver.Crypt
+	database          string
+	deployment        driver.Deployment
+	selector          description.ServerSelector
+	writeConcern      *writeconcern.WriteConcern
+	result            DropIndexesResult
+	serverDescription description.Server
+	serverAPI         *driver.ServerAPIOptions
+	timeout           *time.Duration
+	operationName     string
 }
 
 // DropIndexesResult represents a dropIndexes result returned by the server.
@@ -74,8 +76,13 @@ func NewDropIndexes(index string) *DropIndexes {
 // Result returns the result of executing this operation.
 func (di *DropIndexes) Result() DropIndexesResult { return di.result }
 
+// ServerDescription returns the description of the server that executed this operation. It is the zero value if the
+// operation has not received a response.
+func (di *DropIndexes) ServerDescription() description.Server { return di.serverDescription }
+
 func (di *DropIndexes) processResponse(info driver.ResponseInfo) error {
 	var err error
+	di.serverDescription = info.ConnectionDescription
 	di.result, err = buildDropIndexesResult(info.ServerResponse)
 	return err
 }
@@ -101,6 +108,7 @@ func (di *DropIndexes) Execute(ctx context.Context) error {
 		ServerAPI:         di.serverAPI,
 		Timeout:           di.timeout,
 		Name:              driverutil.DropIndexesOp,
+		OperationName:     di.operationName,
 	}.Execute(ctx)
 
 }
@@ -242,3 +250,13 @@ func (di *DropIndexes) Timeout(timeout *time.Duration) *DropIndexes {
 	di.timeout = timeout
 	return di
 }
+
+// OperationName sets an application-provided name for this operation that is included in command monitoring events.
+func (di *DropIndexes) OperationName(name string) *DropIndexes {
+	if di == nil {
+		di = new(DropIndexes)
+	}
+
+	di.operationName = name
+	return di
+}
@@ -11,9 +11,11 @@ import (
 	"errors"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/internal/driverutil"
 	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
@@ -21,19 +23,23 @@ import (
 
 // ListIndexes performs a listIndexes operation.
 type ListIndexes struct {
-	batchSize  *int32
-	maxTime    *time.Duration
-	session    *session.Client
-	clock      *session.ClusterClock
-	collection string
-	monitor    *event.CommandMonitor
-	database   string
-	deployment driver.Deployment
-	selector   description.ServerSelector
-	retry      *driver.RetryMode
-	crypt      driver.Crypt
-	serverAPI  *driver.ServerAPIOptions
-	timeout    *time.Duration
+	batchSize        *int32
+	comment          bsoncore.Value
+	includeBuildInfo *bool
+	maxTime          *time.Duration
+	readConcern      *readconcern.ReadConcern
+	session          *session.Client
+	clock            *session.ClusterClock
+	collection       string
+	monitor          *event.CommandMonitor
+	database         string
+	deployment       driver.Deployment
+	selector         description.ServerSelector
+	retry            *driver.RetryMode
+	crypt            driver.Crypt
+	serverAPI        *driver.ServerAPIOptions
+	timeout          *time.Duration
+	operationName    string
 
 	result driver.CursorResponse
 }
@@ -77,6 +83,7 @@ func (li *ListIndexes) Execute(ctx context.Context) error {
 		Database:       li.database,
 		Deployment:     li.deployment,
 		MaxTime:        li.maxTime,
+		ReadConcern:    li.readConcern,
 		Selector:       li.selector,
 		Crypt:          li.crypt,
 		Legacy:         driver.LegacyListIndexes,
@@ -85,6 +92,11 @@ func (li *ListIndexes) Execute(ctx context.Context) error {
 		ServerAPI:      li.serverAPI,
 		Timeout:        li.timeout,
 		Name:           driverutil.ListIndexesOp,
+		OperationName:  li.operationName,
+
+		// listIndexes only accepts a read concern on server wire version 9 or above, so it is omitted for older
+		// servers rather than causing an error.
+		MinimumReadConcernWireVersion: 9,
 	}.Execute(ctx)
 
 }
@@ -99,10 +111,38 @@ func (li *ListIndexes) command(dst []byte, _ description.SelectedServer) ([]byte
 	}
 	cursorDoc, _ = bsoncore.AppendDocumentEnd(cursorDoc, cursorIdx)
 	dst = bsoncore.AppendDocumentElement(dst, "cursor", cursorDoc)
+	if li.comment.Type != bsontype.Type(0) {
+		dst = bsoncore.AppendValueElement(dst, "comment", li.comment)
+	}
+	if li.includeBuildInfo != nil {
+		dst = bsoncore.AppendBooleanElement(dst, "includeIndexBuildInfo", *li.includeBuildInfo)
+	}
 
 	return dst, nil
 }
 
+// IncludeBuildInfo specifies whether the server should include indexes that are being built and their build
+// information in the results.
+func (li *ListIndexes) IncludeBuildInfo(include bool) *ListIndexes {
+	if li == nil {
+		li = new(ListIndexes)
+	}
+
+	li.includeBuildInfo = &include
+	return li
+}
+
+// ReadConcern specifies the read concern for this operation. It is only sent to servers with a wire version of 9 or
+// above. The server only accepts the "local" level for listIndexes.
+func (li *ListIndexes) ReadConcern(readConcern *readconcern.ReadConcern) *ListIndexes {
+	if li == nil {
+		li = new(ListIndexes)
+	}
+
+	li.readConcern = readConcern
+	return li
+}
+
 // BatchSize specifies the number of documents to return in every batch.
 func (li *ListIndexes) BatchSize(batchSize int32) *ListIndexes {
 	if li == nil {
@@ -113,6 +153,16 @@ func (li *ListIndexes) BatchSize(batchSize int32) *ListIndexes {
 	return li
 }
 
+// Comment sets a value to help trace an operation.
+func (li *ListIndexes) Comment(comment bsoncore.Value) *ListIndexes {
+	if li == nil {
+		li = new(ListIndexes)
+	}
+
+	li.comment = comment
+	return li
+}
+
 // MaxTime specifies the maximum amount of time to allow the query to run on the server.
 func (li *ListIndexes) MaxTime(maxTime *time.Duration) *ListIndexes {
 	if li == nil {
@@ -233,3 +283,13 @@ func (li *ListIndexes) Timeout(timeout *time.Duration) *ListIndexes {
 	li.timeout = timeout
 	return li
 }
+
+// OperationName sets an application-provided name for this operation that is included in command monitoring events.
+func (li *ListIndexes) OperationName(name string) *ListIndexes {
+	if li == nil {
+		li = new(ListIndexes)
+	}
+
+	li.operationName = name
+	return li
+}